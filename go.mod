@@ -2,6 +2,10 @@ module github.com/e6a5/zenta
 
 go 1.23
 
-require golang.org/x/term v0.24.0
+require (
+	golang.org/x/crypto v0.27.0
+	golang.org/x/term v0.24.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require golang.org/x/sys v0.25.0 // indirect
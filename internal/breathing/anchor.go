@@ -0,0 +1,283 @@
+package breathing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// anchorBarWidth is the width of anchor's breathing bar, in dots
+const anchorBarWidth = 40
+
+// DefaultMaxInhale is how long a full inhale takes to fill the anchor bar
+const DefaultMaxInhale = 4 * time.Second
+
+// The phases of a user-led breath in anchor mode
+const (
+	phaseInhale  = "inhale"
+	phaseHoldIn  = "hold in" // Holding after the inhale
+	phaseExhale  = "exhale"
+	phaseHoldOut = "hold out" // Holding after the exhale
+	phasePaused  = "paused"   // The bar is empty, waiting for the next inhale
+)
+
+// AnchorOptions shape an anchor session
+type AnchorOptions struct {
+	Guide     *Guide        // Ghost pacer to follow, or nil
+	MaxInhale time.Duration // How long a full inhale takes; the exhale empties at the same rate
+	Breaths   int           // End after this many breaths; 0 runs until quit
+}
+
+// StartAnchor provides an immediate, intuitive manual breathing session.
+// It returns the breaths the user completed, for describing their rhythm.
+// With a guide, a faint ghost marker breathes at the guide's rhythm
+// alongside the user's own bar.
+func (s *Session) StartAnchor(opts AnchorOptions) []Breath {
+	if opts.MaxInhale <= 0 {
+		opts.MaxInhale = DefaultMaxInhale
+	}
+
+	// Hide cursor and restore on exit
+	defer s.HideCursor()()
+
+	fmt.Println()
+	PrintWithPadding("   🌸")
+	PrintWithPadding("   Let the rhythm guide you. [SPACE] to switch phase, [h] to hold, [q] to quit.")
+	if opts.Guide != nil {
+		PrintWithPadding(fmt.Sprintf("   Follow the faint %s at %s (%.1f breaths/min).", ghostMarker, opts.Guide.Pattern.Name, opts.Guide.BPMAt(0)))
+	}
+	if opts.Breaths > 0 {
+		PrintWithPadding(fmt.Sprintf("   The session ends after %d breaths.", opts.Breaths))
+	}
+	fmt.Println()
+
+	breaths, err := s.runAnchorBreathing(opts)
+	if err != nil {
+		// If real-time mode fails, print an informative error.
+		PrintWithPadding("Error: This terminal does not support this mode.")
+		PrintWithPadding("The 'zenta now' command is a great alternative.")
+		fmt.Println()
+		return nil
+	}
+
+	// Clean up the final line of the visualizer and add mindful spacing.
+	fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
+	PrintWithPadding("   🙏 Carry this calm with you.")
+	AddBottomPadding()
+	return breaths
+}
+
+// runAnchorBreathing sets up the terminal and runs the new pacer logic,
+// timing each phase the user moves through.
+func (s *Session) runAnchorBreathing(opts AnchorOptions) ([]Breath, error) {
+	if !tty.IsTerminal(os.Stdin) {
+		return nil, fmt.Errorf("stdin is not a terminal")
+	}
+
+	// Switch to raw mode to read single key presses.
+	restore, err := tty.Raw()
+	if err != nil {
+		return nil, err
+	}
+	// Ensure the terminal state is always restored.
+	defer restore()
+
+	var (
+		keyPress = tty.Keys()
+		start    = time.Now()
+		anchor   = newAnchorState(opts.MaxInhale, opts.Breaths, start)
+	)
+
+	// The main animation loop.
+	for {
+		// 1. Check for user input to change phase.
+		select {
+		case key, ok := <-keyPress:
+			if !ok {
+				return anchor.rhythm.breaths, nil // Channel closed.
+			}
+			if key == 'q' || key == 'Q' || key == tty.CtrlC {
+				return anchor.rhythm.breaths, nil
+			}
+			anchor.press(key, time.Now())
+		default:
+			// No input, continue the current phase.
+		}
+
+		// 2. Let the bar follow the time spent in the phase.
+		now := time.Now()
+		if anchor.update(now) {
+			s.drawBreathingVisual(0, anchorBarWidth, anchor.phase, -1, anchor.status(now))
+			return anchor.rhythm.breaths, nil
+		}
+
+		// 3. Render the visual and pause.
+		size := int(anchor.fill*anchorBarWidth + 0.5)
+		label, ghost := anchor.phase, -1
+		if opts.Guide != nil {
+			ghostPhase, ghostFill := opts.Guide.At(now.Sub(start))
+			ghost = int(ghostFill*anchorBarWidth + 0.5)
+			label = fmt.Sprintf("%s · %s", anchor.phase, paceStatus(anchor.phase, anchor.fill, ghostPhase, ghostFill))
+		}
+		s.drawBreathingVisual(size, anchorBarWidth, label, ghost, anchor.status(now))
+		time.Sleep(90 * time.Millisecond) // Slower, more calming pace.
+	}
+}
+
+// anchorState follows the user's breath: the phase they're in, how full
+// the bar is, and the breaths recorded so far. The bar fills and empties
+// with the time spent inhaling and exhaling, reaching full after maxInhale.
+type anchorState struct {
+	phase     string
+	fill      float64 // 0 (empty) to 1 (full)
+	startFill float64 // The fill when the phase began
+	since     time.Time
+	maxInhale time.Duration
+	goal      int // Breaths to take before ending; 0 for no goal
+	rhythm    *rhythmRecorder
+}
+
+// newAnchorState begins with an inhale from an empty bar
+func newAnchorState(maxInhale time.Duration, goal int, now time.Time) *anchorState {
+	return &anchorState{
+		phase:     phaseInhale,
+		since:     now,
+		maxInhale: maxInhale,
+		goal:      goal,
+		rhythm:    newRhythmRecorder(now),
+	}
+}
+
+// press moves between phases. Space turns the breath: inhale (or a hold
+// after it) to exhale, and exhale, a hold after it or a pause to the next
+// inhale. h holds the breath, or releases a hold into the next phase.
+func (a *anchorState) press(key byte, now time.Time) {
+	switch key {
+	case ' ':
+		switch a.phase {
+		case phaseInhale, phaseHoldIn:
+			a.enter(phaseExhale, now)
+		default:
+			a.enter(phaseInhale, now)
+		}
+	case 'h', 'H':
+		switch a.phase {
+		case phaseInhale:
+			a.enter(phaseHoldIn, now)
+		case phaseHoldIn:
+			a.enter(phaseExhale, now)
+		case phaseExhale, phasePaused:
+			a.enter(phaseHoldOut, now)
+		case phaseHoldOut:
+			a.enter(phaseInhale, now)
+		}
+	}
+}
+
+// update sets the fill for the time spent in the current phase, pausing
+// once an exhale runs out. It reports whether the breath goal is reached,
+// which happens as the last breath's exhale ends.
+func (a *anchorState) update(now time.Time) bool {
+	step := float64(now.Sub(a.since)) / float64(a.maxInhale)
+	switch a.phase {
+	case phaseInhale:
+		a.fill = min(a.startFill+step, 1)
+	case phaseExhale:
+		a.fill = max(a.startFill-step, 0)
+		if a.fill == 0 {
+			a.enter(phasePaused, now)
+		}
+	}
+
+	// The exhale is over once the user holds, pauses or breathes in again
+	if a.goal > 0 && a.phase != phaseInhale && a.phase != phaseHoldIn && a.phase != phaseExhale &&
+		a.rhythm.completed() == a.goal-1 {
+		a.rhythm.finish(now)
+	}
+	return a.goal > 0 && a.rhythm.completed() >= a.goal
+}
+
+// enter starts a new phase from the current fill
+func (a *anchorState) enter(phase string, now time.Time) {
+	a.rhythm.enter(phase, now)
+	a.phase, a.since, a.startFill = phase, now, a.fill
+}
+
+// breath returns the number of the breath in progress
+func (a *anchorState) breath() int {
+	n := a.rhythm.completed() + 1
+	if a.goal > 0 && n > a.goal {
+		return a.goal
+	}
+	return n
+}
+
+// status shows the time in the current phase and the breath count, like
+// "2.4s · 3/10"
+func (a *anchorState) status(now time.Time) string {
+	count := fmt.Sprintf("%d", a.breath())
+	if a.goal > 0 {
+		count = fmt.Sprintf("%d/%d", a.breath(), a.goal)
+	}
+	return fmt.Sprintf("%5s · %s", formatSeconds(now.Sub(a.since)), count)
+}
+
+// ghostMarker is the guide's faint marker on the anchor bar
+const ghostMarker = "◇"
+
+// drawBreathingVisual renders a simple, minimalist line of dots, followed
+// by a status such as the phase timer. A ghost position of zero or more
+// draws the guide's marker there.
+func (s *Session) drawBreathingVisual(size, visualMaxWidth int, phase string, ghost int, status string) {
+	var bar strings.Builder
+	bar.WriteString(strings.Repeat(" ", LeftPadding)) // Indent
+
+	// Display the current phase, padded for alignment.
+	width := 8
+	if ghost >= 0 {
+		width = 18 // Room for "hold out · in step"
+	}
+	phaseText := fmt.Sprintf("%-*s", width, phase)
+	bar.WriteString(phaseText)
+	bar.WriteString(" [")
+
+	displaySize := size
+	if displaySize > visualMaxWidth {
+		displaySize = visualMaxWidth
+	}
+
+	cells := make([]string, 0, visualMaxWidth)
+	for i := 0; i < displaySize; i++ {
+		cells = append(cells, "●")
+	}
+
+	if displaySize < visualMaxWidth {
+		cells = append(cells, "○")
+	}
+
+	for len(cells) < visualMaxWidth {
+		cells = append(cells, "·")
+	}
+
+	// The ghost sits on the last cell it has filled
+	if ghost >= 0 {
+		i := ghost - 1
+		if i < 0 {
+			i = 0
+		}
+		if i >= visualMaxWidth {
+			i = visualMaxWidth - 1
+		}
+		cells[i] = "\033[2m" + ghostMarker + "\033[0m"
+	}
+
+	bar.WriteString(strings.Join(cells, ""))
+	bar.WriteString("] ")
+	bar.WriteString(status)
+
+	// Overwrite the current line with the new visual.
+	fmt.Print("\r" + bar.String() + " ")
+}
@@ -0,0 +1,79 @@
+package breathing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnchorStateFollowsTime(t *testing.T) {
+	start := time.Now()
+	at := func(seconds float64) time.Time {
+		return start.Add(time.Duration(seconds * float64(time.Second)))
+	}
+
+	a := newAnchorState(4*time.Second, 0, start)
+	a.update(at(1))
+	if a.fill != 0.25 {
+		t.Errorf("Expected a quarter-full bar after 1s of a 4s inhale, got %.2f", a.fill)
+	}
+
+	a.update(at(6))
+	if a.phase != phaseInhale || a.fill != 1 {
+		t.Errorf("Expected a full bar that stays inhaling, got %s %.2f", a.phase, a.fill)
+	}
+
+	a.press('h', at(6))
+	a.update(at(9))
+	if a.phase != phaseHoldIn || a.fill != 1 {
+		t.Errorf("Expected a full hold, got %s %.2f", a.phase, a.fill)
+	}
+
+	a.press(' ', at(9))
+	a.update(at(11))
+	if a.phase != phaseExhale || a.fill != 0.5 {
+		t.Errorf("Expected a half-empty exhale, got %s %.2f", a.phase, a.fill)
+	}
+
+	a.update(at(13))
+	if a.phase != phasePaused || a.fill != 0 {
+		t.Errorf("Expected an empty bar to pause, got %s %.2f", a.phase, a.fill)
+	}
+
+	a.press('h', at(14))
+	if a.phase != phaseHoldOut {
+		t.Errorf("Expected h to hold after the exhale, got %s", a.phase)
+	}
+	a.press('h', at(16))
+	if a.phase != phaseInhale || a.breath() != 2 {
+		t.Errorf("Expected releasing the hold to start breath 2, got %s breath %d", a.phase, a.breath())
+	}
+}
+
+func TestAnchorStateBreathGoal(t *testing.T) {
+	start := time.Now()
+	at := func(seconds float64) time.Time {
+		return start.Add(time.Duration(seconds * float64(time.Second)))
+	}
+
+	a := newAnchorState(2*time.Second, 2, start)
+	a.press(' ', at(2))
+	if a.update(at(4)) {
+		t.Fatal("Expected the session to go on after the first breath")
+	}
+	a.press(' ', at(5))
+	if a.breath() != 2 || a.status(at(5)) != " 0.0s · 2/2" {
+		t.Errorf("Expected breath 2 of 2, got %q", a.status(at(5)))
+	}
+
+	a.update(at(7))
+	a.press(' ', at(7))
+	if a.update(at(8)) {
+		t.Fatal("Expected the session to go on during the last exhale")
+	}
+	if !a.update(at(9)) {
+		t.Fatal("Expected the session to end as the last exhale empties")
+	}
+	if len(a.rhythm.breaths) != 2 {
+		t.Errorf("Expected both breaths recorded, got %+v", a.rhythm.breaths)
+	}
+}
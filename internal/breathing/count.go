@@ -0,0 +1,137 @@
+package breathing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// CountRound is how many exhales make one round of breath counting
+const CountRound = 10
+
+// CountResult is what a breath-counting session noticed
+type CountResult struct {
+	Rounds   int // Full rounds of ten
+	Breaths  int // Exhales counted
+	Wandered int // Times the user noticed they had lost count
+	Duration time.Duration
+}
+
+// StartCount runs breath counting: the user taps space on each exhale,
+// counting from one to ten and starting over, and presses w on noticing
+// the count was lost. It returns false when the terminal can't run it.
+func (s *Session) StartCount() (CountResult, bool) {
+	// Hide cursor and restore on exit
+	defer s.HideCursor()()
+
+	fmt.Println()
+	PrintWithPadding("   🔢")
+	PrintWithPadding("   Count each exhale from one to ten, then begin again.")
+	PrintWithPadding("   [SPACE] on each exhale, [w] when you notice you've lost count, [q] to finish.")
+	fmt.Println()
+
+	result, err := s.runCount()
+	if err != nil {
+		PrintWithPadding("Error: This terminal does not support this mode.")
+		PrintWithPadding("The 'zenta now' command is a great alternative.")
+		fmt.Println()
+		return result, false
+	}
+
+	fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
+	return result, true
+}
+
+// runCount reads key presses until the user finishes
+func (s *Session) runCount() (CountResult, error) {
+	if !tty.IsTerminal(os.Stdin) {
+		return CountResult{}, fmt.Errorf("stdin is not a terminal")
+	}
+
+	restore, err := tty.Raw()
+	if err != nil {
+		return CountResult{}, err
+	}
+	defer restore()
+
+	start := time.Now()
+	var counter breathCounter
+	s.drawCount(counter)
+	for key := range tty.Keys() {
+		if key == 'q' || key == 'Q' || key == tty.CtrlC {
+			break
+		}
+		counter.press(key)
+		s.drawCount(counter)
+	}
+
+	result := counter.result
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// breathCounter keeps the count through a session
+type breathCounter struct {
+	count    int  // The exhale just counted, 0 before the first of a round
+	wandered bool // The last key was w
+	result   CountResult
+}
+
+// press counts an exhale on space, and starts over from one on w
+func (c *breathCounter) press(key byte) {
+	switch key {
+	case ' ':
+		if c.count == CountRound {
+			c.count = 0
+		}
+		c.count++
+		c.wandered = false
+		c.result.Breaths++
+		if c.count == CountRound {
+			c.result.Rounds++
+		}
+	case 'w', 'W':
+		c.count = 0
+		c.wandered = true
+		c.result.Wandered++
+	}
+}
+
+// drawCount shows the count as a short line of dots, one per exhale
+func (s *Session) drawCount(c breathCounter) {
+	label := "ready"
+	switch {
+	case c.wandered:
+		label = "again"
+	case c.count > 0:
+		label = fmt.Sprintf("%d", c.count)
+	}
+
+	dots := strings.Repeat("●", c.count) + strings.Repeat("·", CountRound-c.count)
+	line := fmt.Sprintf("%s%-8s [%s]", strings.Repeat(" ", LeftPadding), label, dots)
+	if c.result.Rounds > 0 {
+		line += fmt.Sprintf("  \033[2m○ %d\033[0m", c.result.Rounds) // Rounds completed, faintly
+	}
+	fmt.Print("\r" + line + "\033[K")
+}
+
+// PrintCount shows what a counting session noticed
+func PrintCount(result CountResult) {
+	PrintWithPadding(fmt.Sprintf("   🔢 %d full %s of ten · %d %s counted",
+		result.Rounds, plural(result.Rounds, "round", "rounds"), result.Breaths, plural(result.Breaths, "breath", "breaths")))
+	if result.Wandered > 0 {
+		PrintWithPadding(fmt.Sprintf("   🌿 You noticed wandering %d %s. Each noticing is the practice.",
+			result.Wandered, plural(result.Wandered, "time", "times")))
+	}
+}
+
+// plural picks the singular or plural form of a word for n
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,26 @@
+package breathing
+
+import "testing"
+
+func TestBreathCounter(t *testing.T) {
+	var c breathCounter
+	for i := 0; i < 12; i++ {
+		c.press(' ')
+	}
+	if c.count != 2 || c.result.Rounds != 1 || c.result.Breaths != 12 {
+		t.Errorf("Expected count 2 after a full round, got %d (%+v)", c.count, c.result)
+	}
+
+	c.press('w')
+	if c.count != 0 || !c.wandered || c.result.Wandered != 1 {
+		t.Errorf("Expected w to start over, got %d (%+v)", c.count, c.result)
+	}
+
+	for i := 0; i < 9; i++ {
+		c.press(' ')
+	}
+	c.press('x') // Ignored
+	if c.count != 9 || c.wandered || c.result.Rounds != 1 {
+		t.Errorf("Expected 9 counted in the second round, got %d (%+v)", c.count, c.result)
+	}
+}
@@ -0,0 +1,151 @@
+package breathing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSlowOver is how long a guide takes to slow to its target rate
+const DefaultSlowOver = 5 * time.Minute
+
+// paceTolerance is how far apart, in phases, the user and the guide may be
+// while still counting as in step
+const paceTolerance = 0.15
+
+// Guide paces anchor mode with a ghost breathing at a target rhythm. It
+// may slow gradually towards a target rate, keeping the pattern's
+// proportions.
+type Guide struct {
+	Pattern  Pattern
+	SlowTo   float64       // Breaths per minute to reach; 0 keeps the pattern's rate
+	SlowOver time.Duration // Time taken to reach SlowTo
+
+	cycleStart time.Duration // When the ghost's current breath began
+	cycleScale float64       // Stretch applied to the current breath
+}
+
+// NewGuide creates a guide for a pattern, slowing to slowTo breaths per
+// minute over the given time when slowTo is above zero
+func NewGuide(p Pattern, slowTo float64, over time.Duration) *Guide {
+	return &Guide{Pattern: p, SlowTo: slowTo, SlowOver: over, cycleScale: 1}
+}
+
+// BPMAt returns the guide's breathing rate after elapsed time
+func (g *Guide) BPMAt(elapsed time.Duration) float64 {
+	start := 60 / g.Pattern.Cycle().Seconds()
+	if g.SlowTo <= 0 || g.SlowOver <= 0 {
+		return start
+	}
+	progress := math.Min(1, elapsed.Seconds()/g.SlowOver.Seconds())
+	return start + (g.SlowTo-start)*progress
+}
+
+// At returns the ghost's phase ("inhale", "hold", "exhale" or "pause")
+// and how full its breath is, from 0 to 1, after elapsed time. Each breath
+// keeps the pace it started with; elapsed must not go backwards.
+func (g *Guide) At(elapsed time.Duration) (string, float64) {
+	cycle := g.scaled(g.Pattern.Cycle())
+	for elapsed-g.cycleStart >= cycle {
+		g.cycleStart += cycle
+		g.cycleScale = 60 / g.BPMAt(g.cycleStart) / g.Pattern.Cycle().Seconds()
+		cycle = g.scaled(g.Pattern.Cycle())
+	}
+
+	t := elapsed - g.cycleStart
+	phases := []struct {
+		name string
+		d    time.Duration
+		fill func(float64) float64
+	}{
+		{"inhale", g.scaled(g.Pattern.Inhale), func(p float64) float64 { return p }},
+		{"hold", g.scaled(g.Pattern.Hold), func(float64) float64 { return 1 }},
+		{"exhale", g.scaled(g.Pattern.Exhale), func(p float64) float64 { return 1 - p }},
+		{"pause", g.scaled(g.Pattern.Pause), func(float64) float64 { return 0 }},
+	}
+	for _, phase := range phases {
+		if t < phase.d {
+			return phase.name, phase.fill(t.Seconds() / phase.d.Seconds())
+		}
+		t -= phase.d
+	}
+	return "pause", 0
+}
+
+// scaled stretches a phase for the current breath
+func (g *Guide) scaled(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * g.cycleScale)
+}
+
+// paceStatus compares the user's breath with the ghost's, as "ahead",
+// "behind" or "in step". Both are placed on a loop through the four
+// phases, one unit each, and compared the short way round.
+func paceStatus(userPhase string, userFill float64, ghostPhase string, ghostFill float64) string {
+	// A full inhale counts as holding, and an empty pause as pausing
+	if (userPhase == phaseInhale && userFill >= 1 && ghostPhase == "hold") ||
+		(userPhase == phasePaused && ghostPhase == "pause") {
+		return "in step"
+	}
+
+	position := func(phase string, fill float64) float64 {
+		switch phase {
+		case "inhale":
+			return fill
+		case "hold", phaseHoldIn:
+			return 1.5
+		case "exhale":
+			return 2 + (1 - fill)
+		default: // Paused or holding out, or the guide's pause
+			return 3
+		}
+	}
+
+	diff := position(userPhase, userFill) - position(ghostPhase, ghostFill)
+	diff = math.Mod(diff+6, 4) - 2 // Wrap to [-2, 2)
+	switch {
+	case diff > paceTolerance:
+		return "ahead"
+	case diff < -paceTolerance:
+		return "behind"
+	default:
+		return "in step"
+	}
+}
+
+// ParsePatternSpec parses phase lengths in seconds, like "4-6" (inhale,
+// exhale), "4-7-8" (inhale, hold, exhale) or "4-4-4-4" (with a pause)
+func ParsePatternSpec(spec string) (Pattern, error) {
+	parts := strings.Split(spec, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return Pattern{}, fmt.Errorf("invalid rhythm %q (expected seconds like 4-6 or 4-7-8)", spec)
+	}
+
+	seconds := make([]time.Duration, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || v < 0 || v > 60 {
+			return Pattern{}, fmt.Errorf("invalid rhythm %q (expected seconds like 4-6 or 4-7-8)", spec)
+		}
+		seconds[i] = time.Duration(v * float64(time.Second))
+	}
+
+	p := Pattern{Name: spec, Inhale: seconds[0], Exhale: seconds[len(seconds)-1]}
+	switch len(seconds) {
+	case 3:
+		p.Hold = seconds[1]
+	case 4:
+		p.Hold, p.Exhale, p.Pause = seconds[1], seconds[2], seconds[3]
+	}
+	return p, p.validate()
+}
+
+// ParseBPM parses a breathing rate like "6bpm" or "6"
+func ParseBPM(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "bpm"), 64)
+	if err != nil || v <= 0 || v > 60 {
+		return 0, fmt.Errorf("invalid breathing rate %q (expected breaths per minute like 6bpm)", s)
+	}
+	return v, nil
+}
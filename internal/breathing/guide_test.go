@@ -0,0 +1,118 @@
+package breathing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGuideAt(t *testing.T) {
+	g := NewGuide(Pattern{Inhale: 4 * time.Second, Hold: 2 * time.Second, Exhale: 4 * time.Second}, 0, 0)
+
+	testCases := []struct {
+		elapsed time.Duration
+		phase   string
+		fill    float64
+	}{
+		{0, "inhale", 0},
+		{2 * time.Second, "inhale", 0.5},
+		{5 * time.Second, "hold", 1},
+		{7 * time.Second, "exhale", 0.75},
+		{12 * time.Second, "inhale", 0.5}, // Second breath
+	}
+
+	for _, tc := range testCases {
+		phase, fill := g.At(tc.elapsed)
+		if phase != tc.phase || math.Abs(fill-tc.fill) > 1e-9 {
+			t.Errorf("At(%v) = %s %.2f, want %s %.2f", tc.elapsed, phase, fill, tc.phase, tc.fill)
+		}
+	}
+}
+
+func TestGuideSlowsDown(t *testing.T) {
+	// 4-6 is 6 breaths per minute; slow to 3 over one minute
+	g := NewGuide(Pattern{Inhale: 4 * time.Second, Exhale: 6 * time.Second}, 3, time.Minute)
+
+	if got := g.BPMAt(0); got != 6 {
+		t.Errorf("Expected to start at 6 bpm, got %.2f", got)
+	}
+	if got := g.BPMAt(30 * time.Second); got != 4.5 {
+		t.Errorf("Expected 4.5 bpm halfway, got %.2f", got)
+	}
+	if got := g.BPMAt(10 * time.Minute); got != 3 {
+		t.Errorf("Expected to settle at 3 bpm, got %.2f", got)
+	}
+
+	// Walk through the first minute; breaths should get longer
+	for elapsed := time.Duration(0); elapsed <= 2*time.Minute; elapsed += 100 * time.Millisecond {
+		g.At(elapsed)
+	}
+	if g.cycleScale < 1.9 {
+		t.Errorf("Expected breaths to have stretched to about twice as long, scale %.2f", g.cycleScale)
+	}
+}
+
+func TestPaceStatus(t *testing.T) {
+	testCases := []struct {
+		userPhase  string
+		userFill   float64
+		ghostPhase string
+		ghostFill  float64
+		want       string
+	}{
+		{"inhale", 0.5, "inhale", 0.5, "in step"},
+		{"inhale", 0.8, "inhale", 0.4, "ahead"},
+		{"inhale", 0.2, "inhale", 0.6, "behind"},
+		{"exhale", 0.2, "exhale", 0.6, "ahead"},
+		{"exhale", 0.9, "inhale", 0.9, "ahead"},
+		{"inhale", 1, "hold", 1, "in step"},
+		{"paused", 0, "inhale", 0.3, "behind"},
+		{"paused", 0, "pause", 0, "in step"},
+		{"inhale", 0.1, "pause", 0, "ahead"},
+	}
+
+	for _, tc := range testCases {
+		if got := paceStatus(tc.userPhase, tc.userFill, tc.ghostPhase, tc.ghostFill); got != tc.want {
+			t.Errorf("paceStatus(%s %.1f, %s %.1f) = %s, want %s", tc.userPhase, tc.userFill, tc.ghostPhase, tc.ghostFill, got, tc.want)
+		}
+	}
+}
+
+func TestParsePatternSpec(t *testing.T) {
+	testCases := []struct {
+		spec string
+		want Pattern
+	}{
+		{"4-6", Pattern{Inhale: 4 * time.Second, Exhale: 6 * time.Second}},
+		{"4-7-8", Pattern{Inhale: 4 * time.Second, Hold: 7 * time.Second, Exhale: 8 * time.Second}},
+		{"4-4-4-2", Pattern{Inhale: 4 * time.Second, Hold: 4 * time.Second, Exhale: 4 * time.Second, Pause: 2 * time.Second}},
+		{"5.5-5.5", Pattern{Inhale: 5500 * time.Millisecond, Exhale: 5500 * time.Millisecond}},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParsePatternSpec(tc.spec)
+		tc.want.Name = tc.spec
+		if err != nil || got != tc.want {
+			t.Errorf("ParsePatternSpec(%q) = %+v, %v", tc.spec, got, err)
+		}
+	}
+
+	for _, spec := range []string{"4", "4-x", "0-6", "1-2-3-4-5", "coherent"} {
+		if _, err := ParsePatternSpec(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestParseBPM(t *testing.T) {
+	for input, want := range map[string]float64{"6bpm": 6, "6": 6, "5.5BPM": 5.5} {
+		if got, err := ParseBPM(input); err != nil || got != want {
+			t.Errorf("ParseBPM(%q) = %v, %v", input, got, err)
+		}
+	}
+	for _, input := range []string{"fast", "0bpm", "-3"} {
+		if _, err := ParseBPM(input); err == nil {
+			t.Errorf("Expected %q to be rejected", input)
+		}
+	}
+}
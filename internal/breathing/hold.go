@@ -0,0 +1,317 @@
+package breathing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// MaxHold is the longest a breath hold may last. A hold that reaches it
+// ends on its own, however the user feels.
+const MaxHold = 3 * time.Minute
+
+// Limits and defaults for a breath-hold session
+const (
+	DefaultHoldRounds  = 3
+	MaxHoldRounds      = 10
+	DefaultHoldBreaths = 5
+	MaxHoldBreaths     = 30
+)
+
+// Phase lengths of the normal breaths before each hold, and of the
+// recovery breath after it
+const (
+	holdInhale     = 4 * time.Second
+	holdExhale     = 6 * time.Second
+	recoveryInhale = 4 * time.Second
+	recoveryHold   = 10 * time.Second
+	recoveryExhale = 6 * time.Second
+)
+
+// HoldOptions shape a breath-hold session
+type HoldOptions struct {
+	Rounds  int  // Holds to take
+	Breaths int  // Normal breaths before each hold
+	Bolt    bool // Measure a single BOLT hold instead of rounds
+}
+
+// HoldResult is what a breath-hold session measured
+type HoldResult struct {
+	Holds    []time.Duration // Each hold, until the first urge to breathe
+	Bolt     bool
+	Duration time.Duration
+}
+
+// The stages of a breath-hold round
+type holdStage int
+
+const (
+	stageBreathe holdStage = iota // Normal breaths before the hold
+	stageReady                    // BOLT: breathing freely until the user starts the clock
+	stageHold
+	stageRecover // One deep breath, held briefly, after the hold
+	stageDone
+)
+
+// StartHold runs timed breath holds behind a safety notice. In rounds,
+// paced breaths lead into a hold after the exhale, which the user ends
+// with space at the first urge to breathe, then a recovery breath. With
+// Bolt, it measures a single hold after a normal exhale. It returns false
+// when the terminal can't run it.
+func (s *Session) StartHold(opts HoldOptions) (HoldResult, bool) {
+	// Hide cursor and restore on exit
+	defer s.HideCursor()()
+
+	fmt.Println()
+	printHoldSafety()
+	fmt.Println()
+
+	result, err := s.runHold(opts)
+	if err != nil {
+		PrintWithPadding("Error: This terminal does not support this mode.")
+		PrintWithPadding("The 'zenta now' command is a great alternative.")
+		fmt.Println()
+		return result, false
+	}
+
+	fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
+	return result, true
+}
+
+// printHoldSafety shows the notice every breath-hold session begins with
+func printHoldSafety() {
+	PrintWithPadding("   ⚠️  \033[1mBreath holding is not for everyone.\033[0m")
+	PrintWithPadding("   Never practice in or near water, while driving, or standing up.")
+	PrintWithPadding("   Skip it if you are pregnant, or have a heart, lung or blood pressure condition or epilepsy.")
+	PrintWithPadding(fmt.Sprintf("   Stop if you feel dizzy or unwell. Every hold ends on its own after %s.", shortDuration(MaxHold)))
+}
+
+// runHold waits for the user to accept the notice, then reads key presses
+// until the session ends or the user stops it
+func (s *Session) runHold(opts HoldOptions) (HoldResult, error) {
+	if !tty.IsTerminal(os.Stdin) {
+		return HoldResult{Bolt: opts.Bolt}, fmt.Errorf("stdin is not a terminal")
+	}
+
+	restore, err := tty.Raw()
+	if err != nil {
+		return HoldResult{Bolt: opts.Bolt}, err
+	}
+	defer restore()
+
+	keys := tty.Keys()
+	fmt.Print(strings.Repeat(" ", LeftPadding) + "   [SPACE] to begin, [q] to leave.")
+	for {
+		key, ok := <-keys
+		if !ok || key == 'q' || key == 'Q' || key == tty.CtrlC {
+			return HoldResult{Bolt: opts.Bolt}, nil // Left, or the channel closed.
+		}
+		if key == ' ' {
+			break
+		}
+	}
+
+	fmt.Print("\r\033[K")
+	if opts.Bolt {
+		rawLine("   Breathe normally. After a gentle exhale, pinch your nose and press [SPACE].")
+		rawLine("   Press [SPACE] again at the first definite urge to breathe.")
+	} else {
+		rawLine(fmt.Sprintf("   %d %s, each after %d calm breaths. The hold begins after an exhale.",
+			opts.Rounds, plural(opts.Rounds, "hold", "holds"), opts.Breaths))
+		rawLine("   Press [SPACE] at the first urge to breathe. [q] stops at any time.")
+	}
+	rawLine("")
+
+	start := time.Now()
+	h := newHoldState(opts, start)
+	recorded := 0
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok || key == 'q' || key == 'Q' || key == tty.CtrlC {
+				return HoldResult{Holds: h.holds, Bolt: opts.Bolt, Duration: time.Since(start)}, nil
+			}
+			h.press(key, time.Now())
+		default:
+		}
+
+		now := time.Now()
+		done := h.update(now)
+
+		// Keep each round's hold on screen as it ends
+		for ; !opts.Bolt && recorded < len(h.holds); recorded++ {
+			fmt.Print("\r\033[K")
+			rawLine("   " + holdLine(recorded, h.holds[recorded]))
+		}
+		if done {
+			return HoldResult{Holds: h.holds, Bolt: opts.Bolt, Duration: now.Sub(start)}, nil
+		}
+
+		label, fill, status := h.visual(now)
+		s.drawBreathingVisual(int(fill*anchorBarWidth+0.5), anchorBarWidth, label, -1, status)
+		fmt.Print("\033[K") // Statuses vary in length
+		time.Sleep(90 * time.Millisecond)
+	}
+}
+
+// holdState follows a breath-hold session through its rounds
+type holdState struct {
+	opts  HoldOptions
+	stage holdStage
+	round int // Counting from one
+	since time.Time
+	holds []time.Duration
+}
+
+// newHoldState begins with the breaths before the first hold, or, for
+// BOLT, with free breathing until the user starts the clock
+func newHoldState(opts HoldOptions, now time.Time) *holdState {
+	h := &holdState{opts: opts, stage: stageBreathe, round: 1, since: now}
+	if opts.Bolt {
+		h.stage = stageReady
+	}
+	return h
+}
+
+// press handles space: it starts a hold early, or ends one at the urge
+// to breathe
+func (h *holdState) press(key byte, now time.Time) {
+	if key != ' ' {
+		return
+	}
+	switch h.stage {
+	case stageBreathe, stageReady:
+		h.enter(stageHold, now)
+	case stageHold:
+		h.release(now.Sub(h.since), now)
+	}
+}
+
+// update moves through the session's stages as their time runs out. It
+// reports whether the session is over.
+func (h *holdState) update(now time.Time) bool {
+	elapsed := now.Sub(h.since)
+	switch h.stage {
+	case stageBreathe:
+		if elapsed >= time.Duration(h.opts.Breaths)*(holdInhale+holdExhale) {
+			h.enter(stageHold, now)
+		}
+	case stageHold:
+		if elapsed >= MaxHold {
+			h.release(MaxHold, now)
+		}
+	case stageRecover:
+		if elapsed >= recoveryInhale+recoveryHold+recoveryExhale {
+			if h.round < h.opts.Rounds {
+				h.round++
+				h.enter(stageBreathe, now)
+			} else {
+				h.enter(stageDone, now)
+			}
+		}
+	}
+	return h.stage == stageDone
+}
+
+// release records a hold and moves on to recovery, or ends a BOLT
+// measurement
+func (h *holdState) release(held time.Duration, now time.Time) {
+	h.holds = append(h.holds, held)
+	if h.opts.Bolt {
+		h.enter(stageDone, now)
+	} else {
+		h.enter(stageRecover, now)
+	}
+}
+
+// enter starts a stage
+func (h *holdState) enter(stage holdStage, now time.Time) {
+	h.stage, h.since = stage, now
+}
+
+// visual returns the label, bar fill and status for the current moment
+func (h *holdState) visual(now time.Time) (string, float64, string) {
+	elapsed := now.Sub(h.since)
+	round := fmt.Sprintf("round %d/%d", h.round, h.opts.Rounds)
+
+	switch h.stage {
+	case stageBreathe:
+		cycle := holdInhale + holdExhale
+		breath := min(int(elapsed/cycle)+1, h.opts.Breaths)
+		status := fmt.Sprintf("%s · breath %d/%d", round, breath, h.opts.Breaths)
+		if t := elapsed % cycle; t >= holdInhale {
+			return phaseExhale, 1 - float64(t-holdInhale)/float64(holdExhale), status
+		}
+		return phaseInhale, float64(elapsed%cycle) / float64(holdInhale), status
+	case stageReady:
+		return "breathe", 0, "[SPACE] after an exhale"
+	case stageHold:
+		status := formatHold(elapsed) + " · [SPACE] at the first urge"
+		if !h.opts.Bolt {
+			status = round + " · " + status
+		}
+		return "hold", 0, status
+	case stageRecover:
+		switch {
+		case elapsed < recoveryInhale:
+			return "recover", float64(elapsed) / float64(recoveryInhale), "breathe in fully"
+		case elapsed < recoveryInhale+recoveryHold:
+			left := recoveryInhale + recoveryHold - elapsed
+			return "recover", 1, fmt.Sprintf("hold gently · %s", formatSeconds(left))
+		default:
+			t := elapsed - recoveryInhale - recoveryHold
+			return "recover", 1 - float64(t)/float64(recoveryExhale), "let go"
+		}
+	}
+	return "", 0, ""
+}
+
+// holdLine describes a finished hold, like "Round 2  0:41"
+func holdLine(i int, held time.Duration) string {
+	line := fmt.Sprintf("Round %d  %s", i+1, formatHold(held))
+	if held >= MaxHold {
+		line += "  (the limit)"
+	}
+	return line
+}
+
+// rawLine prints a padded line while the terminal is in raw mode, where a
+// newline alone doesn't return to the start of the line
+func rawLine(text string) {
+	fmt.Print(strings.Repeat(" ", LeftPadding) + text + "\r\n")
+}
+
+// formatHold shows a hold as minutes and seconds, like "1:05"
+func formatHold(d time.Duration) string {
+	seconds := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// PrintHold shows what a breath-hold session measured
+func PrintHold(result HoldResult) {
+	if len(result.Holds) == 0 {
+		PrintWithPadding("   🙏 No holds today. That's a fine choice too.")
+		return
+	}
+
+	if result.Bolt {
+		line := fmt.Sprintf("   🫁 Your BOLT score is %ds.", int(result.Holds[0].Seconds()))
+		if result.Holds[0] >= MaxHold {
+			line += " That's the limit, so the hold ended there."
+		}
+		PrintWithPadding(line)
+		return
+	}
+
+	var longest, total time.Duration
+	for _, held := range result.Holds {
+		longest = max(longest, held)
+		total += held
+	}
+	average := total / time.Duration(len(result.Holds))
+	PrintWithPadding(fmt.Sprintf("   🫁 %d %s · longest %s · average %s",
+		len(result.Holds), plural(len(result.Holds), "hold", "holds"), formatHold(longest), formatHold(average)))
+}
@@ -0,0 +1,68 @@
+package breathing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoldRounds(t *testing.T) {
+	start := time.Now()
+	h := newHoldState(HoldOptions{Rounds: 2, Breaths: 3}, start)
+
+	// The hold begins once the breaths are done
+	now := start.Add(3 * (holdInhale + holdExhale))
+	if h.update(now) || h.stage != stageHold {
+		t.Fatalf("Expected a hold after three breaths, got stage %v", h.stage)
+	}
+
+	now = now.Add(40 * time.Second)
+	h.press(' ', now)
+	if h.stage != stageRecover || len(h.holds) != 1 || h.holds[0] != 40*time.Second {
+		t.Fatalf("Expected a 40s hold and recovery, got stage %v and %v", h.stage, h.holds)
+	}
+
+	now = now.Add(recoveryInhale + recoveryHold + recoveryExhale)
+	if h.update(now) || h.stage != stageBreathe || h.round != 2 {
+		t.Fatalf("Expected the second round's breaths, got stage %v round %d", h.stage, h.round)
+	}
+
+	// Space begins the hold early
+	h.press(' ', now)
+	if h.stage != stageHold {
+		t.Fatalf("Expected space to begin the hold, got stage %v", h.stage)
+	}
+
+	// The hard maximum ends a hold the user doesn't
+	now = now.Add(MaxHold + time.Minute)
+	h.update(now)
+	if len(h.holds) != 2 || h.holds[1] != MaxHold {
+		t.Errorf("Expected the hold to stop at %v, got %v", MaxHold, h.holds)
+	}
+
+	if !h.update(now.Add(recoveryInhale + recoveryHold + recoveryExhale)) {
+		t.Error("Expected the session to end after the last recovery breath")
+	}
+}
+
+func TestHoldBolt(t *testing.T) {
+	start := time.Now()
+	h := newHoldState(HoldOptions{Bolt: true}, start)
+
+	// Breathing freely lasts until the user starts the clock
+	if h.update(start.Add(time.Hour)) || h.stage != stageReady {
+		t.Fatalf("Expected to wait for space, got stage %v", h.stage)
+	}
+
+	h.press('x', start)
+	h.press(' ', start)
+	h.press(' ', start.Add(24*time.Second))
+	if !h.update(start.Add(24*time.Second)) || len(h.holds) != 1 || h.holds[0] != 24*time.Second {
+		t.Errorf("Expected a single 24s hold, got %v", h.holds)
+	}
+}
+
+func TestFormatHold(t *testing.T) {
+	if got := formatHold(65 * time.Second); got != "1:05" {
+		t.Errorf("formatHold(65s) = %q, want 1:05", got)
+	}
+}
@@ -0,0 +1,207 @@
+package breathing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// DefaultPattern is the rhythm used when no pattern is chosen
+const DefaultPattern = "box"
+
+// Pattern is a named breathing rhythm. Hold and Pause may be zero to skip
+// those phases.
+type Pattern struct {
+	Name        string
+	Description string
+	Inhale      time.Duration
+	Hold        time.Duration // After the inhale
+	Exhale      time.Duration
+	Pause       time.Duration // After the exhale, resting empty
+}
+
+// builtinPatterns are the rhythms compiled into the binary
+var builtinPatterns = []Pattern{
+	{Name: "box", Description: "Equal sides, steady and balanced", Inhale: 4 * time.Second, Hold: 4 * time.Second, Exhale: 4 * time.Second, Pause: 4 * time.Second},
+	{Name: "calm", Description: "A longer exhale to settle the body", Inhale: 4 * time.Second, Exhale: 6 * time.Second},
+	{Name: "coherent", Description: "Six breaths a minute, for resonance", Inhale: 5 * time.Second, Exhale: 5 * time.Second},
+	{Name: "relax", Description: "4-7-8, for winding down", Inhale: 4 * time.Second, Hold: 7 * time.Second, Exhale: 8 * time.Second},
+}
+
+// yamlPattern is a pattern in the user's patterns file
+type yamlPattern struct {
+	Description string `yaml:"description,omitempty"`
+	Inhale      string `yaml:"inhale"`
+	Hold        string `yaml:"hold,omitempty"`
+	Exhale      string `yaml:"exhale"`
+	Pause       string `yaml:"pause,omitempty"`
+}
+
+// Cycle returns the length of one breath
+func (p Pattern) Cycle() time.Duration {
+	return p.Inhale + p.Hold + p.Exhale + p.Pause
+}
+
+// String shows the pattern's phases in seconds, like "4-7-8-0"
+func (p Pattern) String() string {
+	parts := make([]string, 4)
+	for i, d := range []time.Duration{p.Inhale, p.Hold, p.Exhale, p.Pause} {
+		parts[i] = strings.TrimSuffix(fmt.Sprintf("%.1f", d.Seconds()), ".0")
+	}
+	return strings.Join(parts, "-")
+}
+
+// validate checks that a pattern can be breathed
+func (p Pattern) validate() error {
+	if p.Inhale <= 0 || p.Exhale <= 0 {
+		return fmt.Errorf("pattern %q needs an inhale and an exhale", p.Name)
+	}
+	if p.Hold < 0 || p.Pause < 0 {
+		return fmt.Errorf("pattern %q has a negative phase", p.Name)
+	}
+	return nil
+}
+
+// ApplyPattern sets the session's phase durations from a pattern, to the
+// nearest second
+func (s *Session) ApplyPattern(p Pattern) {
+	seconds := func(d time.Duration, min int) int {
+		return int(math.Max(float64(min), math.Round(d.Seconds())))
+	}
+	s.InhaleDur = seconds(p.Inhale, 1)
+	s.HoldDur = seconds(p.Hold, 0)
+	s.ExhaleDur = seconds(p.Exhale, 1)
+	s.PauseDur = seconds(p.Pause, 0)
+}
+
+// LoadPatterns returns the built-in patterns together with those in the
+// user's patterns file, sorted by name. A user pattern named after a
+// built-in one replaces it. A missing file is not an error.
+func LoadPatterns(path string) ([]Pattern, error) {
+	byName := make(map[string]Pattern)
+	for _, p := range builtinPatterns {
+		byName[p.Name] = p
+	}
+
+	user, err := readPatternFile(path)
+	for name, raw := range user {
+		p, perr := raw.toPattern(name)
+		if perr != nil {
+			err = errors.Join(err, fmt.Errorf("%s: %w", path, perr))
+			continue
+		}
+		byName[name] = p
+	}
+
+	patterns := make([]Pattern, 0, len(byName))
+	for _, p := range byName {
+		patterns = append(patterns, p)
+	}
+	sort.Slice(patterns, func(i, j int) bool { return patterns[i].Name < patterns[j].Name })
+	return patterns, err
+}
+
+// FindPattern returns the pattern called name from the built-in patterns
+// and the user's patterns file
+func FindPattern(name, path string) (Pattern, error) {
+	patterns, err := LoadPatterns(path)
+	names := make([]string, len(patterns))
+	for i, p := range patterns {
+		if p.Name == name {
+			return p, nil
+		}
+		names[i] = p.Name
+	}
+	if err != nil {
+		return Pattern{}, err
+	}
+	return Pattern{}, fmt.Errorf("unknown pattern %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// SavePattern adds or replaces a pattern in the user's patterns file.
+// Phases are kept to a tenth of a second.
+func SavePattern(path string, p Pattern) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	patterns, err := readPatternFile(path)
+	if err != nil {
+		return err
+	}
+	if patterns == nil {
+		patterns = make(map[string]yamlPattern)
+	}
+
+	format := func(d time.Duration) string {
+		if d <= 0 {
+			return ""
+		}
+		return d.Round(100 * time.Millisecond).String()
+	}
+	patterns[p.Name] = yamlPattern{
+		Description: p.Description,
+		Inhale:      format(p.Inhale),
+		Hold:        format(p.Hold),
+		Exhale:      format(p.Exhale),
+		Pause:       format(p.Pause),
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(patterns); err != nil {
+		return err
+	}
+	return storage.WriteFileAtomic(path, buf.Bytes())
+}
+
+// readPatternFile reads the user's patterns file, a mapping from name to
+// phase durations
+func readPatternFile(path string) (map[string]yamlPattern, error) {
+	// #nosec G304 -- reading the user's own patterns file
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns map[string]yamlPattern
+	if err := yaml.Unmarshal(data, &patterns); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+// toPattern parses a pattern's durations
+func (y yamlPattern) toPattern(name string) (Pattern, error) {
+	p := Pattern{Name: name, Description: y.Description}
+	fields := []struct {
+		text string
+		dest *time.Duration
+	}{
+		{y.Inhale, &p.Inhale}, {y.Hold, &p.Hold}, {y.Exhale, &p.Exhale}, {y.Pause, &p.Pause},
+	}
+	for _, f := range fields {
+		if f.text == "" {
+			continue
+		}
+		d, err := time.ParseDuration(f.text)
+		if err != nil {
+			return p, fmt.Errorf("pattern %q: %w", name, err)
+		}
+		*f.dest = d
+	}
+	return p, p.validate()
+}
@@ -0,0 +1,82 @@
+package breathing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuiltinPatternsAreValid(t *testing.T) {
+	for _, p := range builtinPatterns {
+		if err := p.validate(); err != nil {
+			t.Errorf("Built-in pattern %q is invalid: %v", p.Name, err)
+		}
+	}
+}
+
+func TestSaveAndFindPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	mine := Pattern{Name: "mine", Inhale: 3840 * time.Millisecond, Exhale: 4120 * time.Millisecond, Pause: 1210 * time.Millisecond}
+
+	if err := SavePattern(path, mine); err != nil {
+		t.Fatalf("SavePattern failed: %v", err)
+	}
+	// Saving again keeps other patterns
+	if err := SavePattern(path, Pattern{Name: "slow", Inhale: 5 * time.Second, Exhale: 7 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FindPattern("mine", path)
+	if err != nil {
+		t.Fatalf("FindPattern failed: %v", err)
+	}
+	if got.Inhale != 3800*time.Millisecond || got.Exhale != 4100*time.Millisecond || got.Pause != 1200*time.Millisecond || got.Hold != 0 {
+		t.Errorf("Expected phases kept to a tenth of a second, got %s", got)
+	}
+	if _, err := FindPattern("slow", path); err != nil {
+		t.Errorf("Expected the earlier pattern to remain: %v", err)
+	}
+	if _, err := FindPattern("box", path); err != nil {
+		t.Errorf("Expected built-in patterns alongside saved ones: %v", err)
+	}
+	if _, err := FindPattern("nope", path); err == nil || !strings.Contains(err.Error(), "coherent") {
+		t.Errorf("Expected an unknown pattern error listing patterns, got %v", err)
+	}
+}
+
+func TestLoadPatternsReportsInvalidEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	content := "good:\n  inhale: 4s\n  exhale: 6s\nbad:\n  inhale: 4s\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := LoadPatterns(path)
+	if err == nil || !strings.Contains(err.Error(), `"bad"`) {
+		t.Errorf("Expected the pattern without an exhale to be reported, got %v", err)
+	}
+	if len(patterns) != len(builtinPatterns)+1 {
+		t.Errorf("Expected the valid pattern to load, got %d patterns", len(patterns))
+	}
+}
+
+func TestApplyPattern(t *testing.T) {
+	s := NewSession()
+	s.ApplyPattern(Pattern{Inhale: 3800 * time.Millisecond, Exhale: 400 * time.Millisecond, Pause: 1200 * time.Millisecond})
+
+	if s.InhaleDur != 4 || s.HoldDur != 0 || s.ExhaleDur != 1 || s.PauseDur != 1 {
+		t.Errorf("Unexpected durations %d-%d-%d-%d", s.InhaleDur, s.HoldDur, s.ExhaleDur, s.PauseDur)
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	p := Pattern{Inhale: 4 * time.Second, Hold: 7 * time.Second, Exhale: 8 * time.Second}
+	if got := p.String(); got != "4-7-8-0" {
+		t.Errorf("Expected 4-7-8-0, got %s", got)
+	}
+	if got := p.Cycle(); got != 19*time.Second {
+		t.Errorf("Expected a 19s cycle, got %v", got)
+	}
+}
@@ -0,0 +1,165 @@
+package breathing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits on the wall-clock length of a session
+const (
+	MinLength = 10 * time.Second
+	MaxLength = 2 * time.Hour
+)
+
+// Plan sizes the session to last length: as many cycles as fit, with the
+// final cycle stretched or trimmed so the breathing ends on time. A
+// trimmed cycle is kept only if it is at least half a breath long. With a
+// ramp, each cycle takes the rate the ramp has reached as it begins.
+func (s *Session) Plan(length time.Duration) error {
+	if length < MinLength || length > MaxLength {
+		return fmt.Errorf("a session must last between %v and %v", MinLength, shortDuration(MaxLength))
+	}
+
+	if s.ramp != nil {
+		s.planned, s.rates = s.ramp.plan(length)
+	} else {
+		s.planned = fixedPlan(s.cycle(), s.RestDur, length)
+	}
+	s.Cycles = len(s.planned)
+	s.Length = length
+	return nil
+}
+
+// Breaths returns the phase lengths of each cycle of the session
+func (s *Session) Breaths() []Breath {
+	if s.planned != nil {
+		return s.planned
+	}
+
+	breaths := make([]Breath, s.Cycles)
+	for i := range breaths {
+		breaths[i] = s.cycle()
+	}
+	return breaths
+}
+
+// fixedPlan fits cycles of one breath, with rests between them, into length
+func fixedPlan(cycle Breath, rest, length time.Duration) []Breath {
+	n := int((length + rest) / (cycle.Total() + rest))
+	trimmed := length - time.Duration(n)*(cycle.Total()+rest)
+	if n == 0 || trimmed >= cycle.Total()/2 {
+		n++
+	}
+
+	breaths := make([]Breath, n)
+	for i := range breaths {
+		breaths[i] = cycle
+	}
+	// The last cycle takes up whatever time the others leave
+	breaths[n-1] = scaleBreath(cycle, length-time.Duration(n-1)*(cycle.Total()+rest))
+	return breaths
+}
+
+// cycle returns the session's phase lengths
+func (s *Session) cycle() Breath {
+	return Breath{
+		Inhale: time.Duration(s.InhaleDur) * time.Second,
+		Hold:   time.Duration(s.HoldDur) * time.Second,
+		Exhale: time.Duration(s.ExhaleDur) * time.Second,
+		Pause:  time.Duration(s.PauseDur) * time.Second,
+	}
+}
+
+// planSummary describes a planned session, like
+// "⏱  5m · 18 cycles of 4-4-4-4 · ends 14:00"
+func (s *Session) planSummary(now time.Time) string {
+	var rhythm string
+	if s.ramp != nil {
+		rhythm = fmt.Sprintf("from %s to %s bpm", formatBPM(s.ramp.From), formatBPM(s.ramp.To))
+	} else {
+		c := s.cycle()
+		rhythm = "of " + Pattern{Inhale: c.Inhale, Hold: c.Hold, Exhale: c.Exhale, Pause: c.Pause}.String()
+	}
+	return fmt.Sprintf("⏱  %s · %d %s %s · ends %s",
+		shortDuration(s.Length), s.Cycles, plural(s.Cycles, "cycle", "cycles"), rhythm, now.Add(s.Length).Format("15:04"))
+}
+
+// scaleBreath stretches or shrinks a breath to last total, keeping the
+// proportions of its phases
+func scaleBreath(b Breath, total time.Duration) Breath {
+	scale := func(d time.Duration) time.Duration {
+		return time.Duration(float64(d) * float64(total) / float64(b.Total()))
+	}
+	scaled := Breath{Inhale: scale(b.Inhale), Hold: scale(b.Hold), Pause: scale(b.Pause)}
+	scaled.Exhale = total - scaled.Inhale - scaled.Hold - scaled.Pause // Absorbs rounding
+	return scaled
+}
+
+// UntilClock returns how long it is from now until a clock time later
+// today, written like 14:00, 2pm or 2:30pm
+func UntilClock(clock string, now time.Time) (time.Duration, error) {
+	var t time.Time
+	var err error
+	for _, layout := range []string{"15:04", "3:04pm", "3pm"} {
+		if t, err = time.Parse(layout, strings.ToLower(clock)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %s (use a clock time like 14:00 or 2:30pm)", clock)
+	}
+
+	end := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !end.After(now) {
+		return 0, fmt.Errorf("%s has already passed", clock)
+	}
+	return end.Sub(now), nil
+}
+
+// shortDuration formats d to the second without zero units, like 5m or
+// 1h30m
+func shortDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// pacer keeps a session on the clock. Each wait ends at a deadline that
+// follows on from the previous one, so the time spent drawing on a slow
+// terminal doesn't add up over the session.
+type pacer struct {
+	next time.Time
+}
+
+// newPacer starts a pacer at the current time
+func newPacer() *pacer {
+	return &pacer{next: time.Now()}
+}
+
+// wait sleeps until d after the previous deadline
+func (p *pacer) wait(d time.Duration) {
+	p.next = p.next.Add(d)
+	time.Sleep(time.Until(p.next))
+}
+
+// frames returns how many animation frames a phase of length d has: about
+// one a second, and at least one
+func frames(d time.Duration) int {
+	return max(1, int(d.Seconds()+0.5))
+}
+
+// frameStep returns how long frame f (counting from one) of n lasts within
+// a phase of length d, so the frames add up to exactly d
+func frameStep(d time.Duration, f, n int) time.Duration {
+	step := d / time.Duration(n)
+	if f == n {
+		return d - step*time.Duration(n-1)
+	}
+	return step
+}
@@ -0,0 +1,101 @@
+package breathing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlan(t *testing.T) {
+	// Box breathing: 16s cycles with 2s rests between them
+	testCases := []struct {
+		name   string
+		length time.Duration
+		cycles int
+		last   time.Duration
+	}{
+		{"exact fit", 52 * time.Second, 3, 16 * time.Second},
+		{"stretched", 55 * time.Second, 3, 19 * time.Second},
+		{"trimmed", 64 * time.Second, 4, 10 * time.Second},
+		{"shorter than a cycle", 12 * time.Second, 1, 12 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSession()
+			if err := s.Plan(tc.length); err != nil {
+				t.Fatalf("Plan(%v): %v", tc.length, err)
+			}
+			if s.Cycles != tc.cycles {
+				t.Errorf("Expected %d cycles, got %d", tc.cycles, s.Cycles)
+			}
+
+			breaths := s.Breaths()
+			if last := breaths[len(breaths)-1].Total(); last != tc.last {
+				t.Errorf("Expected the last cycle to last %v, got %v", tc.last, last)
+			}
+
+			total := time.Duration(len(breaths)-1) * s.RestDur
+			for _, b := range breaths {
+				total += b.Total()
+			}
+			if total != tc.length {
+				t.Errorf("Expected the session to last %v, got %v", tc.length, total)
+			}
+		})
+	}
+}
+
+func TestPlanLimits(t *testing.T) {
+	for _, length := range []time.Duration{5 * time.Second, 3 * time.Hour} {
+		if err := NewSession().Plan(length); err == nil {
+			t.Errorf("Expected Plan(%v) to fail", length)
+		}
+	}
+}
+
+func TestScaleBreath(t *testing.T) {
+	b := scaleBreath(Breath{Inhale: 4 * time.Second, Exhale: 6 * time.Second}, 15*time.Second)
+
+	if b.Inhale != 6*time.Second || b.Exhale != 9*time.Second || b.Hold != 0 {
+		t.Errorf("Expected 6s in and 9s out, got %+v", b)
+	}
+}
+
+func TestUntilClock(t *testing.T) {
+	now := time.Date(2025, 7, 1, 13, 30, 0, 0, time.Local)
+
+	testCases := []struct {
+		clock string
+		want  time.Duration
+	}{
+		{"14:00", 30 * time.Minute},
+		{"2pm", 30 * time.Minute},
+		{"2:15PM", 45 * time.Minute},
+	}
+	for _, tc := range testCases {
+		got, err := UntilClock(tc.clock, now)
+		if err != nil || got != tc.want {
+			t.Errorf("UntilClock(%q) = %v, %v; want %v", tc.clock, got, err, tc.want)
+		}
+	}
+
+	for _, clock := range []string{"13:30", "9am", "soon"} {
+		if _, err := UntilClock(clock, now); err == nil {
+			t.Errorf("Expected UntilClock(%q) to fail", clock)
+		}
+	}
+}
+
+func TestShortDuration(t *testing.T) {
+	testCases := map[time.Duration]string{
+		5 * time.Minute:                        "5m",
+		90 * time.Minute:                       "1h30m",
+		2 * time.Hour:                          "2h",
+		272*time.Second + 400*time.Millisecond: "4m32s",
+	}
+	for d, want := range testCases {
+		if got := shortDuration(d); got != want {
+			t.Errorf("shortDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package breathing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ramp changes a session's breathing rate gradually, as in resonance
+// breathing, which slows from a natural rate to about six breaths a
+// minute. Each breath keeps the proportions of Shape.
+type Ramp struct {
+	From  float64 // Breaths per minute at the start
+	To    float64 // Breaths per minute at the end
+	Shape Breath
+}
+
+// ApplyRamp sets up the session's Ramp and Ratio. Without a ratio each
+// breath keeps the proportions of the session's pattern, or breathes in
+// and out for equal times when no pattern was chosen. Breaths follow one
+// another without the usual rest, so the rate is the one breathed.
+func (s *Session) ApplyRamp() error {
+	from, to, err := ParseRamp(s.Ramp)
+	if err != nil {
+		return err
+	}
+
+	shape := s.cycle()
+	switch {
+	case s.Ratio != "":
+		if shape, err = ParseRatio(s.Ratio); err != nil {
+			return err
+		}
+	case s.Pattern == "":
+		shape = Breath{Inhale: time.Second, Exhale: time.Second}
+	}
+
+	s.ramp = &Ramp{From: from, To: to, Shape: shape}
+	s.RestDur = 0
+	return nil
+}
+
+// BPMAt returns the rate after progress through the ramp, from 0 to 1
+func (r Ramp) BPMAt(progress float64) float64 {
+	return r.From + (r.To-r.From)*min(1, max(0, progress))
+}
+
+// plan lays out breaths filling length, each at the rate reached as it
+// begins, and returns them with those rates. The last is trimmed to fit if
+// at least half of it remains, and otherwise the one before it stretches
+// to the end.
+func (r Ramp) plan(length time.Duration) ([]Breath, []float64) {
+	var breaths []Breath
+	var rates []float64
+	var elapsed time.Duration
+	for elapsed < length {
+		rate := r.BPMAt(elapsed.Seconds() / length.Seconds())
+		b := scaleBreath(r.Shape, time.Duration(float64(time.Minute)/rate))
+		left := length - elapsed
+		if b.Total() > left {
+			if len(breaths) > 0 && left < b.Total()/2 {
+				last := &breaths[len(breaths)-1]
+				*last = scaleBreath(*last, last.Total()+left)
+			} else {
+				breaths = append(breaths, scaleBreath(b, left))
+				rates = append(rates, rate)
+			}
+			break
+		}
+		breaths = append(breaths, b)
+		rates = append(rates, rate)
+		elapsed += b.Total()
+	}
+	return breaths, rates
+}
+
+// ParseRamp parses the rates a ramp moves between, like "10bpm:6bpm"
+func ParseRamp(spec string) (float64, float64, error) {
+	from, to, found := strings.Cut(spec, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid ramp %q (expected two rates like 10bpm:6bpm)", spec)
+	}
+
+	fromBPM, err := ParseBPM(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	toBPM, err := ParseBPM(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return fromBPM, toBPM, nil
+}
+
+// ParseRatio parses an inhale to exhale ratio like "1:2" as the shape of
+// a breath
+func ParseRatio(spec string) (Breath, error) {
+	in, out, found := strings.Cut(spec, ":")
+	i, err1 := strconv.ParseFloat(strings.TrimSpace(in), 64)
+	e, err2 := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	if !found || err1 != nil || err2 != nil || i <= 0 || e <= 0 || i/e > 10 || e/i > 10 {
+		return Breath{}, fmt.Errorf("invalid ratio %q (expected inhale to exhale like 1:2)", spec)
+	}
+	return Breath{Inhale: time.Duration(i * float64(time.Second)), Exhale: time.Duration(e * float64(time.Second))}, nil
+}
+
+// formatBPM shows a rate to one decimal place, dropping a trailing ".0"
+func formatBPM(rate float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", rate), ".0")
+}
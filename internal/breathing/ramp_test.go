@@ -0,0 +1,89 @@
+package breathing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRampPlan(t *testing.T) {
+	s := NewSession()
+	s.Ramp, s.Ratio = "10bpm:6bpm", "1:2"
+	if err := s.ApplyRamp(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Plan(8 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	breaths := s.Breaths()
+	if s.Cycles != len(breaths) || len(s.rates) != len(breaths) {
+		t.Fatalf("Expected a rate for each of %d cycles, got %d", s.Cycles, len(s.rates))
+	}
+	if s.rates[0] != 10 {
+		t.Errorf("Expected the first breath at 10 bpm, got %v", s.rates[0])
+	}
+
+	var total time.Duration
+	for i, b := range breaths {
+		total += b.Total()
+		if i > 0 && s.rates[i] >= s.rates[i-1] {
+			t.Errorf("Expected the rate to slow, got %v after %v", s.rates[i], s.rates[i-1])
+		}
+		if b.Hold != 0 || b.Pause != 0 {
+			t.Errorf("Expected only an inhale and exhale, got %+v", b)
+		}
+	}
+	if total != 8*time.Minute {
+		t.Errorf("Expected the breaths to fill 8m without rests, got %v", total)
+	}
+
+	first := breaths[0]
+	if first.Inhale != 2*time.Second || first.Exhale != 4*time.Second {
+		t.Errorf("Expected 2s in and 4s out at 10 bpm and 1:2, got %+v", first)
+	}
+}
+
+func TestApplyRampShape(t *testing.T) {
+	// Without a ratio or pattern, in and out are equal
+	s := NewSession()
+	s.Ramp = "12:6"
+	if err := s.ApplyRamp(); err != nil {
+		t.Fatal(err)
+	}
+	if s.ramp.Shape.Inhale != s.ramp.Shape.Exhale || s.ramp.Shape.Hold != 0 {
+		t.Errorf("Expected an even breath, got %+v", s.ramp.Shape)
+	}
+
+	// A pattern lends its proportions
+	s = NewSession()
+	s.Ramp, s.Pattern = "12:6", "relax"
+	s.ApplyPattern(Pattern{Inhale: 4 * time.Second, Hold: 7 * time.Second, Exhale: 8 * time.Second})
+	if err := s.ApplyRamp(); err != nil {
+		t.Fatal(err)
+	}
+	if s.ramp.Shape.Hold != 7*time.Second || s.RestDur != 0 {
+		t.Errorf("Expected the pattern's shape without rests, got %+v and %v", s.ramp.Shape, s.RestDur)
+	}
+}
+
+func TestParseRampAndRatio(t *testing.T) {
+	from, to, err := ParseRamp("10bpm:6bpm")
+	if err != nil || from != 10 || to != 6 {
+		t.Errorf("ParseRamp = %v, %v, %v; want 10, 6", from, to, err)
+	}
+	for _, spec := range []string{"10bpm", "10:x", "0:6"} {
+		if _, _, err := ParseRamp(spec); err == nil {
+			t.Errorf("Expected ParseRamp(%q) to fail", spec)
+		}
+	}
+
+	b, err := ParseRatio("1:1.5")
+	if err != nil || b.Inhale != time.Second || b.Exhale != 1500*time.Millisecond {
+		t.Errorf("ParseRatio = %+v, %v; want 1s and 1.5s", b, err)
+	}
+	for _, spec := range []string{"1", "0:2", "1:20", "a:b"} {
+		if _, err := ParseRatio(spec); err == nil {
+			t.Errorf("Expected ParseRatio(%q) to fail", spec)
+		}
+	}
+}
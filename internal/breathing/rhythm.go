@@ -0,0 +1,156 @@
+package breathing
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MinRhythmBreaths is how many full breaths anchor needs to describe a rhythm
+const MinRhythmBreaths = 3
+
+// Breath holds the phase lengths of one breath, whether user-led in
+// anchor mode or planned for a session
+type Breath struct {
+	Inhale time.Duration
+	Hold   time.Duration // Held after the inhale
+	Exhale time.Duration
+	Pause  time.Duration // Held or resting after the exhale
+}
+
+// Total returns the length of the whole breath
+func (b Breath) Total() time.Duration {
+	return b.Inhale + b.Hold + b.Exhale + b.Pause
+}
+
+// RhythmStats summarizes a user's natural breathing rhythm
+type RhythmStats struct {
+	Breaths     int
+	Inhale      time.Duration // Average of each phase
+	Hold        time.Duration
+	Exhale      time.Duration
+	Pause       time.Duration
+	PerMinute   float64 // Breaths per minute
+	Variability float64 // Spread of breath lengths, as a percentage of the average
+}
+
+// Summarize averages the recorded breaths
+func Summarize(breaths []Breath) RhythmStats {
+	stats := RhythmStats{Breaths: len(breaths)}
+	if len(breaths) == 0 {
+		return stats
+	}
+
+	var inhale, hold, exhale, pause, total time.Duration
+	for _, b := range breaths {
+		inhale += b.Inhale
+		hold += b.Hold
+		exhale += b.Exhale
+		pause += b.Pause
+		total += b.Total()
+	}
+	n := time.Duration(len(breaths))
+	stats.Inhale, stats.Hold, stats.Exhale, stats.Pause = inhale/n, hold/n, exhale/n, pause/n
+
+	mean := total.Seconds() / float64(len(breaths))
+	if mean <= 0 {
+		return stats
+	}
+	stats.PerMinute = 60 / mean
+
+	// Coefficient of variation of the breath lengths
+	var squares float64
+	for _, b := range breaths {
+		diff := b.Total().Seconds() - mean
+		squares += diff * diff
+	}
+	stats.Variability = 100 * math.Sqrt(squares/float64(len(breaths))) / mean
+	return stats
+}
+
+// Pattern turns the measured rhythm into a breathing pattern
+func (st RhythmStats) Pattern(name string) Pattern {
+	return Pattern{
+		Name:        name,
+		Description: fmt.Sprintf("Measured in anchor over %d breaths", st.Breaths),
+		Inhale:      st.Inhale,
+		Hold:        st.Hold,
+		Exhale:      st.Exhale,
+		Pause:       st.Pause,
+	}
+}
+
+// PrintRhythm shows the measured rhythm beneath an anchor session
+func PrintRhythm(st RhythmStats) {
+	PrintWithPadding(fmt.Sprintf("   〰️  Your rhythm over %d breaths:", st.Breaths))
+	phases := "inhale " + formatSeconds(st.Inhale)
+	if st.Hold > 0 {
+		phases += " · hold " + formatSeconds(st.Hold)
+	}
+	phases += fmt.Sprintf(" · exhale %s · pause %s", formatSeconds(st.Exhale), formatSeconds(st.Pause))
+	PrintWithPadding("      " + phases)
+	PrintWithPadding(fmt.Sprintf("      %.1f breaths/min · ±%.0f%% variability", st.PerMinute, st.Variability))
+}
+
+// formatSeconds shows a duration in seconds to one decimal place
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// rhythmRecorder times anchor's phases as the user switches between them
+type rhythmRecorder struct {
+	breaths []Breath
+	current Breath
+	phase   string
+	since   time.Time
+}
+
+// newRhythmRecorder starts timing with the first inhale
+func newRhythmRecorder(now time.Time) *rhythmRecorder {
+	return &rhythmRecorder{phase: phaseInhale, since: now}
+}
+
+// enter records the end of the current phase and the start of the next.
+// A breath is complete when a new inhale follows its exhale.
+func (r *rhythmRecorder) enter(phase string, now time.Time) {
+	if phase == r.phase {
+		return
+	}
+
+	r.record(now)
+	if phase == phaseInhale && r.current.Exhale > 0 {
+		r.breaths = append(r.breaths, r.current)
+		r.current = Breath{}
+	}
+	r.phase, r.since = phase, now
+}
+
+// finish ends the session, keeping the last breath if its exhale is done
+func (r *rhythmRecorder) finish(now time.Time) {
+	r.record(now)
+	if r.current.Exhale > 0 {
+		r.breaths = append(r.breaths, r.current)
+		r.current = Breath{}
+	}
+	r.since = now
+}
+
+// record adds the time spent in the current phase to the current breath
+func (r *rhythmRecorder) record(now time.Time) {
+	elapsed := now.Sub(r.since)
+	switch r.phase {
+	case phaseInhale:
+		r.current.Inhale += elapsed
+	case phaseHoldIn:
+		r.current.Hold += elapsed
+	case phaseExhale:
+		r.current.Exhale += elapsed
+	case phaseHoldOut, phasePaused:
+		r.current.Pause += elapsed
+	}
+}
+
+// completed returns how many full breaths have been recorded
+func (r *rhythmRecorder) completed() int {
+	return len(r.breaths)
+}
@@ -0,0 +1,88 @@
+package breathing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	breaths := []Breath{
+		{Inhale: 4 * time.Second, Exhale: 5 * time.Second, Pause: 1 * time.Second},
+		{Inhale: 4 * time.Second, Exhale: 5 * time.Second, Pause: 1 * time.Second},
+		{Inhale: 5 * time.Second, Exhale: 6 * time.Second, Pause: 4 * time.Second},
+		{Inhale: 3 * time.Second, Exhale: 4 * time.Second, Pause: 2 * time.Second},
+	}
+
+	stats := Summarize(breaths)
+
+	if stats.Breaths != 4 || stats.Inhale != 4*time.Second || stats.Exhale != 5*time.Second || stats.Pause != 2*time.Second {
+		t.Errorf("Unexpected averages %+v", stats)
+	}
+	if math.Abs(stats.PerMinute-5.4545) > 0.01 {
+		t.Errorf("Expected about 5.45 breaths/min, got %.2f", stats.PerMinute)
+	}
+	if stats.Variability <= 0 {
+		t.Errorf("Expected some variability, got %.1f", stats.Variability)
+	}
+
+	if empty := Summarize(nil); empty.Breaths != 0 || empty.PerMinute != 0 {
+		t.Errorf("Expected empty stats, got %+v", empty)
+	}
+}
+
+func TestRhythmRecorder(t *testing.T) {
+	start := time.Now()
+	at := func(seconds float64) time.Time {
+		return start.Add(time.Duration(seconds * float64(time.Second)))
+	}
+
+	r := newRhythmRecorder(start)
+	r.enter("inhale", at(1)) // No change
+	r.enter("exhale", at(4))
+	r.enter("paused", at(9))
+	r.enter("inhale", at(10))
+	r.enter("exhale", at(13))
+	r.enter("paused", at(17)) // Quit during the pause: not a full breath
+
+	if len(r.breaths) != 1 {
+		t.Fatalf("Expected 1 complete breath, got %d", len(r.breaths))
+	}
+	want := Breath{Inhale: 4 * time.Second, Exhale: 5 * time.Second, Pause: time.Second}
+	if r.breaths[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, r.breaths[0])
+	}
+}
+
+func TestRhythmRecorderHolds(t *testing.T) {
+	start := time.Now()
+	at := func(seconds float64) time.Time {
+		return start.Add(time.Duration(seconds * float64(time.Second)))
+	}
+
+	r := newRhythmRecorder(start)
+	r.enter(phaseHoldIn, at(4))
+	r.enter(phaseExhale, at(11))
+	r.enter(phasePaused, at(19))
+	r.enter(phaseHoldOut, at(20))
+	r.enter(phaseInhale, at(22))
+	r.enter(phaseExhale, at(26)) // Straight into the exhale, no hold
+	r.finish(at(32))
+
+	want := []Breath{
+		{Inhale: 4 * time.Second, Hold: 7 * time.Second, Exhale: 8 * time.Second, Pause: 3 * time.Second},
+		{Inhale: 4 * time.Second, Exhale: 6 * time.Second},
+	}
+	if len(r.breaths) != len(want) {
+		t.Fatalf("Expected %d breaths, got %+v", len(want), r.breaths)
+	}
+	for i := range want {
+		if r.breaths[i] != want[i] {
+			t.Errorf("Breath %d: expected %+v, got %+v", i+1, want[i], r.breaths[i])
+		}
+	}
+
+	if stats := Summarize(r.breaths); stats.Pattern("held").Hold != 3500*time.Millisecond {
+		t.Errorf("Expected the average hold in the pattern, got %+v", stats)
+	}
+}
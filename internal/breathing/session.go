@@ -10,8 +10,6 @@ import (
 	"strings"
 	"syscall"
 	"time"
-
-	"golang.org/x/term"
 )
 
 // Constants for breathing visualization
@@ -29,8 +27,20 @@ type Session struct {
 	InhaleDur  int
 	HoldDur    int
 	ExhaleDur  int
+	PauseDur   int // Resting empty after the exhale
 	RestDur    time.Duration
 	SimpleMode bool
+	Collection string        // Quote collection to draw from, empty for all
+	Pattern    string        // Named breathing pattern, applied by the caller
+	For        string        // Wall-clock length like 5m, applied by the caller with Plan
+	Until      string        // Clock time to finish by, like 14:00, applied by the caller with Plan
+	Length     time.Duration // Planned length of the breathing; zero runs whole cycles
+	Ramp       string        // Rates to slow between, like 10bpm:6bpm, applied by the caller
+	Ratio      string        // Inhale to exhale for a ramp, like 1:2
+
+	ramp    *Ramp     // Set by ApplyRamp
+	planned []Breath  // Set by Plan
+	rates   []float64 // Breaths per minute of each planned cycle, with a ramp
 }
 
 // sigint defines the signals to listen for to restore the cursor.
@@ -51,15 +61,18 @@ func NewSession() *Session {
 		InhaleDur:  4,
 		HoldDur:    4,
 		ExhaleDur:  4,
+		PauseDur:   4,
 		RestDur:    RestDuration,
 		SimpleMode: shouldUseSimpleAnimation(),
 	}
 }
 
-// ParseArgs parses command line arguments and configures the session
-func (s *Session) ParseArgs(args []string) {
-	for _, arg := range args {
-		switch arg {
+// ParseArgs parses command line arguments and configures the session. It
+// returns an error when a flag is missing its value.
+func (s *Session) ParseArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		var value *string // Where a flag's value goes
+		switch arg := args[i]; arg {
 		case "--quick", "-q":
 			s.Cycles = 1
 		case "--extended", "-e":
@@ -70,8 +83,29 @@ func (s *Session) ParseArgs(args []string) {
 			s.SimpleMode = false
 		case "--simple":
 			s.SimpleMode = true
+		case "--collection", "-c":
+			value = &s.Collection
+		case "--pattern", "-p":
+			value = &s.Pattern
+		case "--for":
+			value = &s.For
+		case "--until":
+			value = &s.Until
+		case "--ramp":
+			value = &s.Ramp
+		case "--ratio":
+			value = &s.Ratio
+		}
+
+		if value != nil {
+			if i+1 >= len(args) {
+				return fmt.Errorf("missing value for %s", args[i])
+			}
+			*value = args[i+1]
+			i++
 		}
 	}
+	return nil
 }
 
 func (s Session) HideCursor() func() {
@@ -93,6 +127,9 @@ func (s *Session) Start() {
 
 	// Go straight into breathing - no interruptions
 	PrintWithPadding("   Let's breathe 🌸")
+	if s.Length > 0 {
+		PrintWithPadding("   \033[2m" + s.planSummary(time.Now()) + "\033[0m")
+	}
 	AddSectionSpacing()
 
 	// Use simple animation for better compatibility or if requested
@@ -134,31 +171,33 @@ func shouldUseSimpleAnimation() bool {
 
 // drawSimpleBreathingSession draws a simple line-based breathing animation for compatibility
 func (s *Session) drawSimpleBreathingSession() {
-	for cycle := 1; cycle <= s.Cycles; cycle++ {
-		// Inhale phase
-		s.drawSimplePhase("🌬️", "Breathe in gently...", s.InhaleDur, []string{
+	breaths := s.Breaths()
+	p := newPacer()
+	for cycle, breath := range breaths {
+		// Inhale phase, with the rate beneath it while it changes
+		s.drawSimplePhase(p, "🌬️", "Breathe in gently...", s.rateNote(cycle), breath.Inhale, []string{
 			"·", "○", "○○", "●○○", "●●○○", "●●●○", "●●●●",
 		})
 
 		// Hold phase
-		s.drawSimplePhase("✨", "Hold softly...", s.HoldDur, []string{
+		s.drawSimplePhase(p, "✨", "Hold softly...", "", breath.Hold, []string{
 			"●●●●", "●●●●", "●●●●", "●●●●",
 		})
 
 		// Exhale phase
-		s.drawSimplePhase("🌸", "Release slowly...", s.ExhaleDur, []string{
+		s.drawSimplePhase(p, "🌸", "Release slowly...", "", breath.Exhale, []string{
 			"●●●●", "●●●○", "●●○○", "●○○○", "○○○○", "○○  ", "○   ",
 		})
 
 		// Rest phase
-		s.drawSimplePhase("🕯️", "Rest in emptiness...", s.HoldDur, []string{
+		s.drawSimplePhase(p, "🕯️", "Rest in emptiness...", "", breath.Pause, []string{
 			"·", "·", "·", "·",
 		})
 
 		// Brief pause between cycles
-		if cycle < s.Cycles {
+		if cycle < len(breaths)-1 && s.RestDur > 0 {
 			PrintWithPadding("   💫 Feel the rhythm... continuing...")
-			time.Sleep(s.RestDur)
+			p.wait(s.RestDur)
 			fmt.Println()
 		}
 	}
@@ -168,21 +207,30 @@ func (s *Session) drawSimpleBreathingSession() {
 }
 
 // drawSimplePhase draws a single breathing phase with progressive animation
-func (s *Session) drawSimplePhase(emoji, instruction string, duration int, patterns []string) {
-	if checkForExit() {
+func (s *Session) drawSimplePhase(p *pacer, emoji, instruction, note string, duration time.Duration, patterns []string) {
+	// Patterns may leave out holds and pauses
+	if duration <= 0 || checkForExit() {
 		return
 	}
 
 	// Show the phase instruction
 	PrintWithPadding(fmt.Sprintf("   %s %s", emoji, instruction))
 
+	if note != "" {
+		PrintWithPadding("      " + note)
+	}
+
 	// Reserve space for the animation
 	PrintWithPadding("      ")
 
-	// Animate through the duration
-	for second := 1; second <= duration; second++ {
+	// Animate through the duration, about a frame a second
+	n := frames(duration)
+	for frame := 1; frame <= n; frame++ {
 		// Choose pattern based on progress through the phase
-		patternIndex := (second - 1) * (len(patterns) - 1) / (duration - 1)
+		patternIndex := len(patterns) - 1
+		if n > 1 {
+			patternIndex = (frame - 1) * (len(patterns) - 1) / (n - 1)
+		}
 		if patternIndex >= len(patterns) {
 			patternIndex = len(patterns) - 1
 		}
@@ -192,7 +240,7 @@ func (s *Session) drawSimplePhase(emoji, instruction string, duration int, patte
 		fmt.Print("\033[1A")
 		PrintWithPadding(fmt.Sprintf("      %s", pattern))
 
-		time.Sleep(time.Second)
+		p.wait(frameStep(duration, frame, n))
 	}
 
 	fmt.Println() // Add spacing after phase
@@ -209,40 +257,54 @@ func (s *Session) drawContinuousBreathingSession() {
 	phases := []struct {
 		name        string
 		emoji       string
-		duration    int
+		duration    func(Breath) time.Duration
 		instruction string
 		breathType  string
 	}{
-		{"inhale", "🌬️", s.InhaleDur, "Breathe in gently, let your body expand...", "expand"},
-		{"hold", "✨", s.HoldDur, "Hold softly, feel the fullness...", "full"},
-		{"exhale", "🌸", s.ExhaleDur, "Release slowly, let everything go...", "contract"},
-		{"rest", "🕯️", s.HoldDur, "Rest in the emptiness, be present...", "empty"},
+		{"inhale", "🌬️", func(b Breath) time.Duration { return b.Inhale }, "Breathe in gently, let your body expand...", "expand"},
+		{"hold", "✨", func(b Breath) time.Duration { return b.Hold }, "Hold softly, feel the fullness...", "full"},
+		{"exhale", "🌸", func(b Breath) time.Duration { return b.Exhale }, "Release slowly, let everything go...", "contract"},
+		{"rest", "🕯️", func(b Breath) time.Duration { return b.Pause }, "Rest in the emptiness, be present...", "empty"},
 	}
 
 	// One lung, breathing continuously through all cycles
-	for cycle := 1; cycle <= s.Cycles; cycle++ {
+	breaths := s.Breaths()
+	p := newPacer()
+	for cycle, breath := range breaths {
 		for _, phase := range phases {
 			if checkForExit() {
 				return
 			}
+			duration := phase.duration(breath)
+			if duration <= 0 {
+				continue // Patterns may leave out holds and pauses
+			}
 
 			// Show gentle guidance for each phase
 			showBreathingGuidance(phase.emoji, phase.name, phase.instruction)
 
 			// Animate the same breathing circle for this phase
-			animateBreathingCircle(phase.breathType, phase.duration)
+			animateBreathingCircle(p, phase.breathType, duration, s.rateNote(cycle))
 		}
 
 		// Brief pause between breathing cycles
-		if cycle < s.Cycles {
+		if cycle < len(breaths)-1 && s.RestDur > 0 {
 			showBreathingGuidance("💫", "rest", "Feel the rhythm... continuing...")
-			time.Sleep(s.RestDur)
+			p.wait(s.RestDur)
 		}
 	}
 
 	clearBreathingDisplay()
 }
 
+// rateNote shows the rate of a cycle faintly while a ramp changes it
+func (s *Session) rateNote(cycle int) string {
+	if cycle >= len(s.rates) {
+		return ""
+	}
+	return "\033[2m" + formatBPM(s.rates[cycle]) + " bpm\033[0m"
+}
+
 // checkForExit checks if user pressed 'q' to exit (simplified for now)
 func checkForExit() bool {
 	// For now, we'll implement a simple version
@@ -261,12 +323,13 @@ func showBreathingGuidance(emoji, phase, instruction string) {
 }
 
 // animateBreathingCircle creates an organic breathing circle that expands/contracts
-func animateBreathingCircle(breathType string, duration int) {
+func animateBreathingCircle(p *pacer, breathType string, duration time.Duration, note string) {
 	// Position circle in the center of the reserved area (relative to current cursor)
 	centerRowOffset := 5          // 5 lines down from guidance text
 	centerCol := LeftPadding + 25 // Centered position
 
-	for second := 1; second <= duration; second++ {
+	n := frames(duration)
+	for frame := 1; frame <= n; frame++ {
 		if checkForExit() {
 			return
 		}
@@ -278,7 +341,7 @@ func animateBreathingCircle(breathType string, duration int) {
 		switch breathType {
 		case "expand":
 			// Gradually expand from 1 to 4
-			progress := float64(second) / float64(duration)
+			progress := float64(frame) / float64(n)
 			circleSize = 1 + int(progress*3)
 			circleChar = "○"
 		case "full":
@@ -287,7 +350,7 @@ func animateBreathingCircle(breathType string, duration int) {
 			circleChar = "●"
 		case "contract":
 			// Gradually contract from 4 to 1
-			progress := float64(second) / float64(duration)
+			progress := float64(frame) / float64(n)
 			circleSize = 4 - int(progress*3)
 			circleChar = "○"
 		case "empty":
@@ -299,8 +362,11 @@ func animateBreathingCircle(breathType string, duration int) {
 		// Clear previous circle and draw new one
 		clearCircleAreaRelative(centerRowOffset, centerCol)
 		drawBreathingCircleRelative(centerRowOffset, centerCol, circleSize, circleChar)
+		if note != "" {
+			drawAtPositionRelative(1, LeftPadding+3, note) // Beneath the guidance
+		}
 
-		time.Sleep(time.Second)
+		p.wait(frameStep(duration, frame, n))
 	}
 }
 
@@ -406,142 +472,3 @@ func AddBottomPadding() {
 		fmt.Println()
 	}
 }
-
-// StartAnchor provides an immediate, intuitive manual breathing session.
-func (s *Session) StartAnchor() {
-	// Hide cursor and restore on exit
-	defer s.HideCursor()()
-
-	fmt.Println()
-	PrintWithPadding("   🌸")
-	PrintWithPadding("   Let the rhythm guide you. [SPACE] to switch phase, [q] to quit.")
-	fmt.Println()
-
-	if err := s.runAnchorBreathing(); err != nil {
-		// If real-time mode fails, print an informative error.
-		PrintWithPadding("Error: This terminal does not support this mode.")
-		PrintWithPadding("The 'zenta now' command is a great alternative.")
-		fmt.Println()
-		return
-	}
-
-	// Clean up the final line of the visualizer and add mindful spacing.
-	fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
-	PrintWithPadding("   🙏 Carry this calm with you.")
-	AddBottomPadding()
-}
-
-// runAnchorBreathing sets up the terminal and runs the new pacer logic.
-func (s *Session) runAnchorBreathing() error {
-	fd := int(os.Stdin.Fd())
-	if !term.IsTerminal(fd) {
-		return fmt.Errorf("stdin is not a terminal")
-	}
-
-	// Switch to raw mode to read single key presses.
-	oldState, err := term.MakeRaw(fd)
-	if err != nil {
-		return err
-	}
-	// Ensure the terminal state is always restored.
-	defer func() {
-		if err := term.Restore(fd, oldState); err != nil {
-			fmt.Fprintf(os.Stderr, "Error restoring terminal: %v\n", err)
-		}
-	}()
-
-	var (
-		breathSize int
-		maxSize    = 40
-		phase      = "inhale" // "inhale", "exhale", or "paused"
-		keyPress   = make(chan byte, 1)
-	)
-
-	// Goroutine to read key presses.
-	go func() {
-		defer close(keyPress)
-		for {
-			buffer := make([]byte, 1)
-			if count, err := os.Stdin.Read(buffer); err != nil || count == 0 {
-				return
-			}
-			keyPress <- buffer[0]
-		}
-	}()
-
-	// The main animation loop.
-	for {
-		// 1. Check for user input to change phase.
-		select {
-		case key, ok := <-keyPress:
-			if !ok {
-				return nil // Channel closed.
-			}
-			switch key {
-			case ' ':
-				if phase == "inhale" {
-					phase = "exhale" // Switch to exhaling.
-				} else if phase == "paused" {
-					phase = "inhale" // Start new cycle from paused state.
-				}
-			case 'q', 'Q', 3: // Ctrl+C
-				return nil
-			}
-		default:
-			// No input, continue the current phase.
-		}
-
-		// 2. Update the breath size based on the current phase.
-		switch phase {
-		case "inhale":
-			if breathSize < maxSize {
-				breathSize++
-			}
-		case "exhale":
-			if breathSize > 0 {
-				breathSize--
-			} else {
-				phase = "paused" // Breath is empty, wait for user.
-			}
-		case "paused":
-			// Do nothing, wait for the user to press space.
-		}
-
-		// 3. Render the visual and pause.
-		s.drawBreathingVisual(breathSize, maxSize, phase)
-		time.Sleep(90 * time.Millisecond) // Slower, more calming pace.
-	}
-}
-
-// drawBreathingVisual renders a simple, minimalist line of dots.
-func (s *Session) drawBreathingVisual(size, visualMaxWidth int, phase string) {
-	var bar strings.Builder
-	bar.WriteString(strings.Repeat(" ", LeftPadding)) // Indent
-
-	// Display the current phase, padded for alignment.
-	phaseText := fmt.Sprintf("%-8s", phase)
-	bar.WriteString(phaseText)
-	bar.WriteString(" [")
-
-	displaySize := size
-	if displaySize > visualMaxWidth {
-		displaySize = visualMaxWidth
-	}
-
-	for i := 0; i < displaySize; i++ {
-		bar.WriteString("●")
-	}
-
-	if displaySize < visualMaxWidth {
-		bar.WriteString("○")
-	}
-
-	for i := 0; i < visualMaxWidth-displaySize-1; i++ {
-		bar.WriteString("·")
-	}
-
-	bar.WriteString("]")
-
-	// Overwrite the current line with the new visual.
-	fmt.Print("\r" + bar.String() + " ")
-}
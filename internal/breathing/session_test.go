@@ -1,6 +1,7 @@
 package breathing
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -22,6 +23,9 @@ func TestNewSession(t *testing.T) {
 	if s.ExhaleDur != 4 {
 		t.Errorf("Expected ExhaleDur to be 4, got %d", s.ExhaleDur)
 	}
+	if s.PauseDur != 4 {
+		t.Errorf("Expected PauseDur to be 4, got %d", s.PauseDur)
+	}
 	if s.SimpleMode != shouldUseSimpleAnimation() {
 		t.Errorf("Expected SimpleMode to match default from shouldUseSimpleAnimation()")
 	}
@@ -47,6 +51,7 @@ func TestParseArgs(t *testing.T) {
 		{"-e", []string{"-e"}, 5, true, defaultSimple},
 		{"-s", []string{"-s"}, 3, false, defaultSimple},
 		{"combo", []string{"--quick", "--silent", "--simple"}, 1, false, true},
+		{"collection", []string{"--collection", "stoic", "--quick"}, 1, true, defaultSimple},
 	}
 
 	for _, tc := range testCases {
@@ -80,3 +85,40 @@ func TestShouldShowQuote(t *testing.T) {
 		t.Error("ShouldShowQuote should return false when s.ShowQuote is false")
 	}
 }
+
+func TestParseArgsCollection(t *testing.T) {
+	s := NewSession()
+	s.ParseArgs([]string{"--extended", "--collection", "tao"})
+
+	if s.Collection != "tao" {
+		t.Errorf("Expected collection 'tao', got %q", s.Collection)
+	}
+
+}
+
+func TestParseArgsMissingValue(t *testing.T) {
+	for _, flag := range []string{"--collection", "-p", "--for", "--until", "--ramp", "--ratio"} {
+		s := NewSession()
+		if err := s.ParseArgs([]string{"--quick", flag}); err == nil || !strings.Contains(err.Error(), flag) {
+			t.Errorf("Expected an error naming %s, got %v", flag, err)
+		}
+	}
+}
+
+func TestParseArgsPattern(t *testing.T) {
+	s := NewSession()
+	s.ParseArgs([]string{"--pattern", "mine", "-q"})
+
+	if s.Pattern != "mine" || s.Cycles != 1 {
+		t.Errorf("Expected pattern mine and 1 cycle, got %q and %d", s.Pattern, s.Cycles)
+	}
+}
+
+func TestParseArgsLength(t *testing.T) {
+	s := NewSession()
+	s.ParseArgs([]string{"--for", "5m", "--until", "14:00"})
+
+	if s.For != "5m" || s.Until != "14:00" {
+		t.Errorf("Expected --for 5m and --until 14:00, got %q and %q", s.For, s.Until)
+	}
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+)
+
+// requireValue returns the value following the flag at position i,
+// exiting with a helpful message when it is missing.
+func requireValue(args []string, i int) string {
+	if i+1 >= len(args) {
+		exitWithError("Missing value for %s", args[i])
+	}
+	return args[i+1]
+}
+
+// exitWithError prints an error message to stderr and exits
+func exitWithError(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/config"
+	"github.com/e6a5/zenta/internal/practice"
+)
+
+// HandleCount handles the 'count' command: counting exhales from one to
+// ten, starting over whenever the count is lost. It reports whether any
+// breaths were counted.
+func HandleCount(args []string) bool {
+	for _, arg := range args {
+		exitWithError("Unknown option: %s", arg)
+	}
+
+	logging := practiceLogging()
+	if logging {
+		requireVault()
+	}
+
+	session := breathing.NewSession()
+	result, ok := session.StartCount()
+	if !ok {
+		return false
+	}
+
+	breathing.PrintCount(result)
+	if logging && result.Breaths > 0 {
+		recordPractice(practice.Entry{
+			Practice: "count",
+			Seconds:  int(result.Duration.Round(time.Second).Seconds()),
+			Rounds:   result.Rounds,
+			Breaths:  result.Breaths,
+			Wandered: result.Wandered,
+		})
+	}
+	breathing.AddBottomPadding()
+	return result.Breaths > 0
+}
+
+// practiceLogging reports whether the user has turned on the practice log
+func practiceLogging() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+	return cfg.Log.Enabled
+}
+
+// recordPractice adds a finished session to the practice log, warning
+// rather than failing when it can't be written
+func recordPractice(entry practice.Entry) {
+	entry.Time = time.Now()
+	if err := practice.Append(practice.LogPath(), entry); err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: could not record the session: %v\n", err)
+	}
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/githooks"
+)
+
+// HandleGit handles the 'git' command family for mindful git hooks
+func HandleGit(args []string, programName string) {
+	if len(args) == 0 {
+		exitWithError("Usage: %s git install-hooks|uninstall-hooks [--pre-commit] [--pre-push] [--post-merge]", programName)
+	}
+
+	switch args[0] {
+	case "install-hooks":
+		handleInstallHooks(args[1:], programName)
+	case "uninstall-hooks":
+		handleUninstallHooks(args[1:])
+	case "run-hook":
+		handleRunHook(args[1:])
+	default:
+		exitWithError("Unknown git subcommand: %s", args[0])
+	}
+}
+
+// parseHookArgs collects the hooks selected by flags and the requested mode
+func parseHookArgs(args []string) ([]string, githooks.Mode) {
+	var hooks []string
+	mode := githooks.ModeBreathe
+
+	for _, arg := range args {
+		switch arg {
+		case "--quote":
+			mode = githooks.ModeQuote
+		case "--breathe":
+			mode = githooks.ModeBreathe
+		default:
+			name := strings.TrimPrefix(arg, "--")
+			if name == arg || !githooks.IsSupported(name) {
+				exitWithError("Unknown option: %s", arg)
+			}
+			hooks = append(hooks, name)
+		}
+	}
+
+	return hooks, mode
+}
+
+// handleInstallHooks writes zenta hooks into the current repository
+func handleInstallHooks(args []string, programName string) {
+	hooks, mode := parseHookArgs(args)
+	if len(hooks) == 0 {
+		hooks = []string{githooks.DefaultHook}
+	}
+
+	dir, err := githooks.HooksDir()
+	if err != nil {
+		exitWithError("Error: %v", err)
+	}
+
+	for _, hook := range hooks {
+		if err := githooks.Install(dir, hook, programName, mode); err != nil {
+			exitWithError("Error installing %s hook: %v", hook, err)
+		}
+		fmt.Printf("Installed mindful %s hook 🌸\n", hook)
+	}
+}
+
+// handleUninstallHooks removes zenta hooks, restoring any chained hooks
+func handleUninstallHooks(args []string) {
+	hooks, _ := parseHookArgs(args)
+	if len(hooks) == 0 {
+		hooks = githooks.Hooks
+	}
+
+	dir, err := githooks.HooksDir()
+	if err != nil {
+		exitWithError("Error: %v", err)
+	}
+
+	for _, hook := range hooks {
+		removed, err := githooks.Uninstall(dir, hook)
+		switch {
+		case errors.Is(err, githooks.ErrNotOurs):
+			fmt.Printf("Left %s hook untouched (not installed by zenta)\n", hook)
+		case err != nil:
+			exitWithError("Error removing %s hook: %v", hook, err)
+		case removed:
+			fmt.Printf("Removed %s hook\n", hook)
+		}
+	}
+}
+
+// handleRunHook runs the mindful pause from inside an installed hook.
+// It never fails, so it can't block a commit or push.
+func handleRunHook(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	_, mode := parseHookArgs(args[1:])
+
+	if githooks.SkipReason(os.Getenv) != "" || !hasInteractiveTerminal() {
+		return
+	}
+
+	if mode == githooks.ModeQuote {
+		displayQuote(nextQuote(newQuoteService(""), "git"))
+		return
+	}
+
+	session := breathing.NewSession()
+	session.Cycles = 1
+	session.ShowQuote = false
+
+	defer session.HideCursor()()
+	session.Start()
+}
+
+// hasInteractiveTerminal reports whether a person is likely watching, as
+// opposed to an IDE git pane or another tool driving git.
+func hasInteractiveTerminal() bool {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return false
+	}
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	_ = tty.Close()
+	return true
+}
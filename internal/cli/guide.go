@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/guide"
+	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/sit"
+	"github.com/e6a5/zenta/internal/storage"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// HandleGuide handles the 'guide' command: running, listing and checking
+// guided session scripts. It reports whether a script ran to its end.
+func HandleGuide(args []string, programName string) bool {
+	if len(args) == 0 {
+		exitWithError("Usage: %s guide <name|file> | guide list | guide validate <file>", programName)
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		listGuides()
+		return false
+	case "validate":
+		if len(args) != 2 {
+			exitWithError("Usage: %s guide validate <file>", programName)
+		}
+		validateGuide(args[1])
+		return false
+	}
+	if len(args) > 1 {
+		exitWithError("Unknown option: %s", args[1])
+	}
+
+	script, err := guide.Find(args[0], guideDir())
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	// Fail before the session starts, not in the middle of it
+	if problems := scriptProblems(script); len(problems) > 0 {
+		exitWithError("%s", strings.Join(problems, "; "))
+	}
+
+	r := &guideSession{out: os.Stdout, autoAdvance: reflectConfig().AutoAdvance}
+	defer r.attach()()
+	return r.run(script)
+}
+
+// guideSession runs a guided script. With keys it is paced by the user:
+// timed lines can be skipped, and prompts wait for a key press.
+type guideSession struct {
+	out         io.Writer
+	keys        <-chan byte   // Nil when not interactive
+	restore     func()        // Leaves raw mode; nil when not interactive
+	autoAdvance time.Duration // Move on from a prompt after this long; 0 waits
+	write       bool          // Offer a line under each prompt for an answer
+	answers     []string      // Written answers, by prompt
+	reflected   bool          // Prompts have been shown, so quotes follow a reflection
+}
+
+// attach pairs the session with the keyboard when someone is there,
+// returning a func that lets it go
+func (r *guideSession) attach() func() {
+	if !tty.IsInteractive() {
+		return func() {}
+	}
+	restore, err := tty.Raw()
+	if err != nil {
+		return func() {}
+	}
+	tty.Drain()
+	r.restore = restore
+	r.keys = tty.Keys()
+	r.out = tty.NewlineWriter(os.Stdout)
+
+	return func() {
+		if r.restore != nil {
+			r.restore()
+		}
+	}
+}
+
+// cooked runs f with the terminal in its normal mode, for steps that
+// manage the terminal themselves
+func (r *guideSession) cooked(f func()) {
+	if r.restore == nil {
+		f()
+		return
+	}
+
+	r.restore()
+	f()
+	restore, err := tty.Raw()
+	if err != nil {
+		// Carry on with timed pauses
+		r.restore, r.keys, r.out = nil, nil, os.Stdout
+		return
+	}
+	tty.Drain()
+	r.restore = restore
+}
+
+// run shows the whole script, stopping early if the user quits. It
+// reports whether every step was taken.
+func (r *guideSession) run(script guide.Script) bool {
+	defer r.blank(breathing.BottomPadding)
+
+	// Begin the session
+	r.println(script.Title)
+	if !r.pause(reflection.TitlePause) {
+		return false
+	}
+	r.blank(breathing.SectionSpacing)
+
+	for _, step := range script.Steps {
+		if !r.runStep(step) {
+			return false
+		}
+	}
+	return true
+}
+
+// runStep takes one step of a script, returning false if the user quit
+func (r *guideSession) runStep(step guide.Step) bool {
+	switch step.Kind {
+	case guide.StepSay:
+		if step.Text == "" {
+			r.blank(1)
+		} else {
+			r.println(linePrefix + step.Text)
+		}
+		return r.pause(step.Pause)
+
+	case guide.StepPause:
+		return r.pause(step.Pause)
+
+	case guide.StepWait:
+		if r.keys == nil {
+			return true // No one to wait for
+		}
+		text := step.Text
+		if text == "" {
+			text = "Press any key to continue"
+		}
+		fmt.Fprintf(r.out, "%s%s%s", strings.Repeat(" ", breathing.LeftPadding), linePrefix, dim(text))
+		key, _ := r.waitForKey(0)
+		fmt.Fprint(r.out, "\r\033[K")
+		return promptKeyAction(key) != quitReflection
+
+	case guide.StepBreathe:
+		r.cooked(func() { breatheCycles(step.Cycles, step.Pattern) })
+
+	case guide.StepQuote:
+		r.cooked(func() { displayQuote(nextQuote(newQuoteService(step.Collection), r.quoteSession())) })
+
+	case guide.StepSit:
+		return r.sit(step.Duration)
+
+	case guide.StepPrompts:
+		r.reflected = true
+		return r.prompts(step)
+	}
+	return true
+}
+
+// quoteSession names the session a quote step follows, for quote rules:
+// "reflect" once the script has asked its prompts, "guide" before
+func (r *guideSession) quoteSession() string {
+	if r.reflected {
+		return "reflect"
+	}
+	return "guide"
+}
+
+// prompts introduces and walks through a step's reflection prompts
+func (r *guideSession) prompts(step guide.Step) bool {
+	if step.Text != "" {
+		title := linePrefix + step.Text
+		switch {
+		case r.write:
+			title += "  " + dim("enter: save · ↑: back")
+		case r.keys != nil:
+			title += "  " + dim("space: next · b: back")
+		}
+		r.println(title)
+		if !r.pause(reflection.PromptTitlePause) {
+			return false
+		}
+	}
+	return r.showPrompts(step.Prompts)
+}
+
+// sit waits in silence for d under a dim progress line. Interactively,
+// space pauses and q ends the session.
+func (r *guideSession) sit(d time.Duration) bool {
+	if r.keys == nil {
+		time.Sleep(d)
+		return true
+	}
+
+	timer := sit.NewTimer(d, time.Now())
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for !timer.Done(time.Now()) {
+		drawSit(r.out, timer, time.Now(), true)
+		select {
+		case key, ok := <-r.keys:
+			if !ok {
+				r.keys = nil
+				fmt.Fprint(r.out, "\r\033[K")
+				time.Sleep(timer.Remaining(time.Now()))
+				return true
+			}
+			switch promptKeyAction(key) {
+			case advancePrompt:
+				timer.Toggle(time.Now())
+			case quitReflection:
+				fmt.Fprint(r.out, "\r\033[K\n")
+				return false
+			}
+		case <-ticker.C:
+		}
+	}
+	fmt.Fprint(r.out, "\r\033[K")
+	return true
+}
+
+// breatheCycles runs a short breathing session inside a guided script
+func breatheCycles(cycles int, patternName string) {
+	session := breathing.NewSession()
+	session.Cycles = cycles
+	session.ShowQuote = false
+	if patternName != "" {
+		pattern, err := breathing.FindPattern(patternName, patternsPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+			return
+		}
+		session.ApplyPattern(pattern)
+	}
+
+	defer session.HideCursor()()
+	session.Start()
+}
+
+// listGuides prints the available scripts
+func listGuides() {
+	scripts, errs := guide.Available(guideDir())
+	printLoadErrors(errs)
+	for _, script := range scripts {
+		fmt.Printf("  %-16s %s  (%d steps, %s)\n", script.Name, script.Title, len(script.Steps), origin(script.Source))
+	}
+}
+
+// printLoadErrors reports the user's files that could not be loaded
+func printLoadErrors(errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+}
+
+// origin names the file something was loaded from, or says it is built in
+func origin(source string) string {
+	if source == "" {
+		return "built-in"
+	}
+	return source
+}
+
+// validateGuide checks a script file, including the patterns and quote
+// collections it names, and reports what it found
+func validateGuide(path string) {
+	script, err := guide.LoadFile(path)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	if problems := scriptProblems(script); len(problems) > 0 {
+		exitWithError("%s: %s", path, strings.Join(problems, "; "))
+	}
+
+	fmt.Printf("✓ %s: %s, %d steps\n", path, script.Title, len(script.Steps))
+}
+
+// scriptProblems lists the patterns and quote collections a script names
+// that can't be found
+func scriptProblems(script guide.Script) []string {
+	var problems []string
+	for _, name := range script.Patterns() {
+		if _, err := breathing.FindPattern(name, patternsPath()); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	collections := newQuoteService("").Collections()
+	for _, step := range script.Steps {
+		if step.Kind == guide.StepQuote && step.Collection != "" && !slices.Contains(collections, step.Collection) {
+			problems = append(problems, fmt.Sprintf("unknown quote collection %q", step.Collection))
+		}
+	}
+	return problems
+}
+
+// guideDir is where user guided session scripts live
+func guideDir() string {
+	return filepath.Join(storage.ConfigDir(), "guides")
+}
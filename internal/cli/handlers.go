@@ -5,11 +5,16 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/config"
 	"github.com/e6a5/zenta/internal/quotes"
-	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/storage"
+	"github.com/e6a5/zenta/internal/tty"
 	"github.com/e6a5/zenta/internal/version"
 )
 
@@ -22,7 +27,26 @@ func ShowHelp(programName string) {
 	fmt.Println("USAGE:")
 	fmt.Printf("  %s now [options]         Take a mindful breathing moment\n", programName)
 	fmt.Printf("  %s anchor                Guided breathing anchor\n", programName)
+	fmt.Printf("  %s count                 Count exhales from one to ten (space each exhale, w when lost)\n", programName)
+	fmt.Printf("  %s note-practice [10m]   Sit and label thoughts as they arise (p planning, r remembering, ...)\n", programName)
+	fmt.Printf("  %s sit [20m] [options]   Silent meditation timer with bells (space pauses)\n", programName)
+	fmt.Printf("  %s hold [options]        Timed breath holds, space at the first urge to breathe (read the safety notice)\n", programName)
 	fmt.Printf("  %s reflect               End-of-day reflection on thought patterns\n", programName)
+	fmt.Printf("  %s guide <name|file>     Run a guided session: body-scan, loving-kindness, reflect, or your own\n", programName)
+	fmt.Printf("  %s guide list|validate   List guided sessions, or check a script file\n", programName)
+	fmt.Printf("  %s program start <name>  Follow a multi-day program (intro-7, or your own), one session a day\n", programName)
+	fmt.Printf("  %s program status|list  See your progress, or the available programs (stop to leave)\n", programName)
+	fmt.Printf("  %s today                 Run today's session of your program (--again to repeat it)\n", programName)
+	fmt.Printf("  %s quote [options]       Show a quote on its own\n", programName)
+	fmt.Printf("  %s quote list|search     Browse quotes by collection or text\n", programName)
+	fmt.Printf("  %s quote add \"text\"      Add a quote to your own collection\n", programName)
+	fmt.Printf("  %s quote fav|unfav <id>  Mark a favorite, shown more often\n", programName)
+	fmt.Printf("  %s quote import          Import a fortune(6) database or one-per-line file\n", programName)
+	fmt.Printf("  %s git install-hooks     Pause for a breath before commits or pushes\n", programName)
+	fmt.Printf("  %s git uninstall-hooks   Remove zenta git hooks, restoring any originals\n", programName)
+	fmt.Printf("  %s shell-init <shell>    Print a prompt hook that suggests a breath (bash, zsh, fish)\n", programName)
+	fmt.Printf("  %s vault lock|unlock     Encrypt your written reflections and records with a passphrase\n", programName)
+	fmt.Printf("  %s vault rekey|status    Change the passphrase, or see whether the vault is open\n", programName)
 	fmt.Printf("  %s help                  Show this help message\n", programName)
 	fmt.Println()
 	fmt.Println("NOW OPTIONS:")
@@ -31,6 +55,59 @@ func ShowHelp(programName string) {
 	fmt.Println("  --silent, -s                Breathing only, skip the quote")
 	fmt.Println("  --simple                    Simple line animation (for terminal compatibility)")
 	fmt.Println("  --complex                   Force complex animation (default except on Apple Terminal)")
+	fmt.Println("  --collection, -c NAME       Draw the quote from one collection (zen, stoic, tao, mindfulness, or your own)")
+	fmt.Println("  --pattern, -p NAME          Breathing pattern: box (default), calm, coherent, relax, or one saved from anchor")
+	fmt.Println("  --for DURATION              Breathe for a while, like 5m, fitting the cycles to it")
+	fmt.Println("  --until TIME                Breathe until a clock time, like 14:00 or 2pm")
+	fmt.Println("  --ramp FROM:TO              Change the rate gradually over the session, like 10bpm:6bpm")
+	fmt.Println("  --ratio IN:OUT              Inhale to exhale for a ramp, like 1:2 (default: the pattern's, or 1:1)")
+	fmt.Println()
+	fmt.Println("ANCHOR OPTIONS:")
+	fmt.Println("  --save NAME                 Save the rhythm you find as a pattern for 'now --pattern NAME'")
+	fmt.Println("  --guide, -g RHYTHM          Follow a faint ghost breathing at a rhythm like 4-6, or a pattern name")
+	fmt.Println("  --slow-to RATE              Let the guide slow gradually to a rate like 6bpm")
+	fmt.Println("  --slow-over DURATION        How long the guide takes to slow down (default 5m)")
+	fmt.Println("  --max-inhale DURATION       How long a full inhale takes to fill the bar (default 4s)")
+	fmt.Println("  --breaths, -b N             End the session after N breaths")
+	fmt.Println("                              In a terminal: space turns the breath, h holds, q stops")
+	fmt.Println()
+	fmt.Println("SIT OPTIONS:")
+	fmt.Println("  --interval, -i DURATION     Ring a bell every DURATION, e.g. 5m")
+	fmt.Println("  --prepare DURATION          Count down before the start bell, e.g. 10s")
+	fmt.Println("  --bell STYLE                bel (default), flash, both or none")
+	fmt.Println("  --no-progress               Hide the dim progress line")
+	fmt.Println()
+	fmt.Println("HOLD OPTIONS:")
+	fmt.Println("  --rounds, -r N              Holds to take (default 3, at most 10)")
+	fmt.Println("  --breaths, -b N             Calm breaths before each hold (default 5)")
+	fmt.Println("  --bolt                      Measure a single BOLT hold after a normal exhale")
+	fmt.Println("                              Every hold ends on its own after 3 minutes")
+	fmt.Println()
+	fmt.Println("QUOTE OPTIONS:")
+	fmt.Println("  --today, -t                 Quote of the day (the same all day)")
+	fmt.Println("  --plain, -p                 Print on one line, without the typing effect")
+	fmt.Println("  --style NAME                typewriter, fade or instant (any key finishes a quote)")
+	fmt.Println("  --speed DURATION            Delay per character, e.g. 20ms (0 for instant)")
+	fmt.Println("  --collection, -c NAME       Draw from (or list) one collection")
+	fmt.Println("  --author, --tags, --emoji, --source  Details for 'quote add'")
+	fmt.Println("  --fortune PATH, --lines PATH  Source for 'quote import' (--dat PATH for a strfile index)")
+	fmt.Println("  --into NAME, --max-lines N    Target collection and longest quote to keep (default 4 lines)")
+	fmt.Println()
+	fmt.Println("REFLECT OPTIONS:")
+	fmt.Println("  --set NAME|FILE             Prompt set: evening (default), morning, weekly, retro, or your own")
+	fmt.Println("  --list, -l                  Show the available prompt sets")
+	fmt.Println("  --auto-advance DURATION     Move on from a prompt after this long (default: wait for a key)")
+	fmt.Println("                              In a terminal: space/enter next, b back, q stop")
+	fmt.Println("  --write, -w                 Type a private answer under each prompt")
+	fmt.Println("  --review [DATE]             Read answers you wrote (YYYY-MM-DD, today, yesterday; default latest)")
+	fmt.Println()
+	fmt.Println("SHELL-INIT OPTIONS:")
+	fmt.Println("  --after-failures N          Suggest a breath after N failed commands in a row (default 3)")
+	fmt.Println("  --after DURATION            Suggest a breath after a command runs this long (default 10m)")
+	fmt.Println()
+	fmt.Println("GIT HOOK OPTIONS:")
+	fmt.Println("  --pre-commit, --pre-push, --post-merge  Hooks to install (default: pre-commit)")
+	fmt.Println("  --quote                     Show a quote instead of a breathing cycle")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Printf("  %s now                   Standard 3-cycle breathing session\n", programName)
@@ -38,8 +115,16 @@ func ShowHelp(programName string) {
 	fmt.Printf("  %s now --extended        Extended 5-cycle session\n", programName)
 	fmt.Printf("  %s now --silent          Breathing without quote\n", programName)
 	fmt.Printf("  %s now --simple          Simple animation (terminal compatibility)\n", programName)
+	fmt.Printf("  %s now -c stoic          Close with a Stoic quote\n", programName)
+	fmt.Printf("  %s now --for 5m          Five minutes of breathing, however many cycles fit\n", programName)
+	fmt.Printf("  %s now --ramp 10bpm:6bpm --for 8m  Slow down to resonance breathing\n", programName)
 	fmt.Printf("  %s anchor                Anchor your breath to the present moment\n", programName)
+	fmt.Printf("  %s now --pattern mine    Breathe with the rhythm you saved from anchor\n", programName)
+	fmt.Printf("  %s anchor --guide 4-6    Follow a ghost pacer (4s in, 6s out)\n", programName)
 	fmt.Printf("  %s reflect               Gentle end-of-day reflection\n", programName)
+	fmt.Printf("  %s reflect --set retro   Calm down after an incident\n", programName)
+	fmt.Printf("  %s program start intro-7  Seven days of presence, one short session each day\n", programName)
+	fmt.Printf("  eval \"$(%s shell-init zsh)\"  Invite a breath after long builds or repeated failures\n", programName)
 	fmt.Println()
 	fmt.Println("MINDFUL ALIASES:")
 	fmt.Printf("  alias breath='%s now --quick'\n", programName)
@@ -49,77 +134,267 @@ func ShowHelp(programName string) {
 	fmt.Println("Learn more: https://github.com/e6a5/zenta")
 }
 
-// HandleNow handles the 'now' command for breathing sessions
-func HandleNow(args []string) {
+// HandleNow handles the 'now' command for breathing sessions. It reports
+// whether the session finished, which it always does unless interrupted.
+func HandleNow(args []string) bool {
 	session := breathing.NewSession()
-	session.ParseArgs(args)
+	if err := session.ParseArgs(args); err != nil {
+		exitWithError("%v", err)
+	}
+	if session.Pattern != "" {
+		pattern, err := breathing.FindPattern(session.Pattern, patternsPath())
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		session.ApplyPattern(pattern)
+	}
+	if session.Ramp != "" || session.Ratio != "" {
+		applyRamp(session)
+	}
+	if session.For != "" || session.Until != "" {
+		planSession(session)
+	}
+
+	// Load quotes up front so a bad collection name fails before breathing
+	var quoteService *quotes.QuoteService
+	if session.ShouldShowQuote() {
+		quoteService = newQuoteService(session.Collection)
+	}
 
 	defer session.HideCursor()()
 	session.Start()
 
-	if session.ShouldShowQuote() {
-		quoteService := quotes.New()
-		quote := quoteService.GetRandomQuote()
-		quotes.DisplayBeautifully(quote)
+	if quoteService != nil {
+		quote := nextQuote(quoteService, sessionKind(session.Cycles))
+		displayQuote(quote)
 	} else {
 		breathing.PrintWithPadding("   Carry this calm with you throughout your day 🙏")
 	}
 
 	breathing.AddBottomPadding()
+	return true
 }
 
-// HandleAnchor handles the 'anchor' command for the interactive pacer.
-func HandleAnchor(args []string) {
+// HandleAnchor handles the 'anchor' command for the interactive pacer. It
+// reports whether the session finished with enough breaths to find a rhythm.
+func HandleAnchor(args []string) bool {
+	var saveAs, guideSpec string
+	var slowTo float64
+	slowOver := breathing.DefaultSlowOver
+	opts := breathing.AnchorOptions{MaxInhale: anchorMaxInhale()}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--guide", "-g":
+			guideSpec = requireValue(args, i)
+			i++
+		case "--slow-to":
+			bpm, err := breathing.ParseBPM(requireValue(args, i))
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			slowTo = bpm
+			i++
+		case "--slow-over":
+			d, err := time.ParseDuration(requireValue(args, i))
+			if err != nil || d <= 0 {
+				exitWithError("Invalid duration: %s", args[i+1])
+			}
+			slowOver = d
+			i++
+		case "--max-inhale":
+			d, err := time.ParseDuration(requireValue(args, i))
+			if err != nil || d <= 0 {
+				exitWithError("Invalid duration: %s", args[i+1])
+			}
+			opts.MaxInhale = d
+			i++
+		case "--breaths", "-b":
+			opts.Breaths = parseCount(args[i], requireValue(args, i))
+			i++
+		case "--save":
+			saveAs = requireValue(args, i)
+			if !validName(saveAs) {
+				exitWithError("Invalid pattern name: %s (use letters, digits, - and _)", saveAs)
+			}
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	if guideSpec != "" {
+		opts.Guide = breathing.NewGuide(findGuidePattern(guideSpec), slowTo, slowOver)
+	} else if slowTo > 0 {
+		exitWithError("--slow-to needs a rhythm to start from, like --guide 4-6")
+	}
+
 	session := breathing.NewSession()
-	session.StartAnchor()
+	breaths := session.StartAnchor(opts)
+
+	// Describe the rhythm the user found, and offer to keep it
+	stats := breathing.Summarize(breaths)
+	finished := stats.Breaths >= breathing.MinRhythmBreaths
+	if finished {
+		breathing.PrintRhythm(stats)
+		if saveAs == "" {
+			saveAs = askPatternName()
+		}
+		if saveAs != "" {
+			if err := breathing.SavePattern(patternsPath(), stats.Pattern(saveAs)); err != nil {
+				exitWithError("Saving the pattern: %v", err)
+			}
+			breathing.PrintWithPadding(fmt.Sprintf("   Saved. Breathe with it any time: zenta now --pattern %s", saveAs))
+		}
+		fmt.Println()
+	}
 
 	// Show a quote after the session, unless it was silent.
 	// This check is a placeholder for future flags, e.g., --breathe-silent
 	if session.ShouldShowQuote() {
-		quoteService := quotes.New()
-		quote := quoteService.GetRandomQuote()
-		quotes.DisplayBeautifully(quote)
+		quoteService := newQuoteService(session.Collection)
+		quote := nextQuote(quoteService, "anchor")
+		displayQuote(quote)
+	}
+	return finished
+}
+
+// anchorMaxInhale reads the anchor's full inhale length from the config
+// file, warning about and ignoring a file it can't use
+func anchorMaxInhale() time.Duration {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+		return breathing.DefaultMaxInhale
+	}
+	return cfg.Anchor.MaxInhale
+}
+
+// findGuidePattern resolves --guide: phase lengths like 4-6, or the name
+// of a pattern
+func findGuidePattern(spec string) breathing.Pattern {
+	if pattern, err := breathing.ParsePatternSpec(spec); err == nil {
+		return pattern
+	}
+
+	pattern, err := breathing.FindPattern(spec, patternsPath())
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	return pattern
+}
+
+// askPatternName offers to save a measured rhythm, returning the name
+// typed or "" to skip
+func askPatternName() string {
+	if !tty.IsInteractive() {
+		return ""
+	}
+	restore, err := tty.Raw()
+	if err != nil {
+		return ""
+	}
+	defer restore()
+	tty.Drain()
+
+	out := tty.NewlineWriter(os.Stdout)
+	for {
+		fmt.Fprintf(out, "\n%s   Save as a pattern? Name it, like 'mine' (enter to skip): ", strings.Repeat(" ", breathing.LeftPadding))
+		name, result := tty.ReadLine(tty.Keys(), out, "")
+		fmt.Fprintln(out)
+		name = strings.TrimSpace(name)
+		if result != tty.LineEntered || name == "" {
+			return ""
+		}
+		if validName(name) {
+			return name
+		}
+		fmt.Fprintf(out, "%s   Use letters, digits, - and _ only.\n", strings.Repeat(" ", breathing.LeftPadding))
+	}
+}
+
+// validName reports whether name is safe to use as the name of a pattern
+// or quote collection, which become file names
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// patternsPath is the user's breathing patterns file
+func patternsPath() string {
+	return filepath.Join(storage.ConfigDir(), "patterns.yaml")
+}
+
+// applyRamp sets up --ramp, which needs a length to slow over
+func applyRamp(session *breathing.Session) {
+	if session.Ramp == "" {
+		exitWithError("--ratio goes with --ramp, like --ramp 10bpm:6bpm --ratio 1:2")
+	}
+	if session.For == "" && session.Until == "" {
+		exitWithError("--ramp needs a length to change over, like --for 8m")
+	}
+	if err := session.ApplyRamp(); err != nil {
+		exitWithError("%v", err)
 	}
 }
 
-// HandleReflect handles the 'reflect' command for mindful reflection
-func HandleReflect(args []string) {
-	prompts := reflection.GetDefaultPrompts()
-
-	// Begin the session
-	breathing.PrintWithPadding(prompts.Title)
-	time.Sleep(1 * time.Second)
-	breathing.AddSectionSpacing()
-
-	// Guide through initial instructions with pauses
-	for i, line := range prompts.Instructions {
-		breathing.PrintWithPadding(line)
-		// Give more time for the last instruction (taking breaths)
-		if i == len(prompts.Instructions)-1 {
-			time.Sleep(5 * time.Second)
-		} else {
-			time.Sleep(3 * time.Second)
+// planSession sizes a session from --for or --until
+func planSession(session *breathing.Session) {
+	if session.For != "" && session.Until != "" {
+		exitWithError("Use either --for or --until, not both")
+	}
+
+	var length time.Duration
+	if session.For != "" {
+		d, err := time.ParseDuration(session.For)
+		if err != nil {
+			exitWithError("Invalid duration: %s (use a duration like 5m)", session.For)
 		}
+		length = d
+	} else {
+		d, err := breathing.UntilClock(session.Until, time.Now())
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		length = d
+	}
+
+	if err := session.Plan(length); err != nil {
+		exitWithError("%v", err)
 	}
-	breathing.AddSectionSpacing()
+}
 
-	// Introduce the reflection prompts
-	breathing.PrintWithPadding(prompts.PromptTitle)
-	time.Sleep(2 * time.Second)
+// sessionKind names a breathing session for context-aware quote selection
+func sessionKind(cycles int) string {
+	switch {
+	case cycles <= 1:
+		return "quick"
+	case cycles >= 5:
+		return "extended"
+	default:
+		return "now"
+	}
+}
 
-	// Display each prompt with a long pause for contemplation
-	for _, line := range prompts.Prompts {
-		breathing.PrintWithPadding(line)
-		time.Sleep(8 * time.Second)
+// newQuoteService loads the built-in and user quote collections, reporting
+// malformed user entries on stderr, and selects the requested collection.
+func newQuoteService(collection string) *quotes.QuoteService {
+	quoteService := quotes.New()
+	for _, err := range quoteService.LoadDir(filepath.Join(storage.ConfigDir(), "quotes")) {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
 	}
-	breathing.AddSectionSpacing()
 
-	// Display the closing thoughts with pauses
-	for _, line := range prompts.Closing {
-		breathing.PrintWithPadding(line)
-		time.Sleep(3 * time.Second)
+	if err := quoteService.UseCollection(collection); err != nil {
+		exitWithError("Error: %v (available: %s)", err, strings.Join(quoteService.Collections(), ", "))
 	}
-	breathing.AddBottomPadding()
+	return quoteService
 }
 
 // HandleVersion handles version display
@@ -76,3 +76,20 @@ func TestHandleUnknownCommand(t *testing.T) {
 	// 	t.Errorf("Expected unknown command error for '%s', got '%s'", command, stderr)
 	// }
 }
+
+func TestSessionKind(t *testing.T) {
+	testCases := map[int]string{1: "quick", 3: "now", 5: "extended"}
+	for cycles, want := range testCases {
+		if got := sessionKind(cycles); got != want {
+			t.Errorf("sessionKind(%d) = %q, want %q", cycles, got, want)
+		}
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for name, want := range map[string]bool{"mine": true, "slow-6": true, "evening_4": true, "": false, "../x": false, "my rhythm": false} {
+		if got := validName(name); got != want {
+			t.Errorf("validName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
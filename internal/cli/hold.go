@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/practice"
+)
+
+// holdTrendLength is how many past sessions the trend shows
+const holdTrendLength = 5
+
+// HandleHold handles the 'hold' command: rounds of timed breath holds, or
+// a single BOLT measurement
+func HandleHold(args []string) {
+	opts := breathing.HoldOptions{Rounds: breathing.DefaultHoldRounds, Breaths: breathing.DefaultHoldBreaths}
+	counted := false // Whether --rounds or --breaths was given
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--rounds", "-r":
+			counted = true
+			opts.Rounds = parseCount(args[i], requireValue(args, i))
+			i++
+		case "--breaths", "-b":
+			counted = true
+			opts.Breaths = parseCount(args[i], requireValue(args, i))
+			i++
+		case "--bolt":
+			opts.Bolt = true
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	if opts.Bolt && counted {
+		exitWithError("--bolt is a single measurement and doesn't take --rounds or --breaths")
+	}
+	if opts.Rounds < 1 || opts.Rounds > breathing.MaxHoldRounds {
+		exitWithError("--rounds must be between 1 and %d", breathing.MaxHoldRounds)
+	}
+	if opts.Breaths < 1 || opts.Breaths > breathing.MaxHoldBreaths {
+		exitWithError("--breaths must be between 1 and %d", breathing.MaxHoldBreaths)
+	}
+
+	logging := practiceLogging()
+	if logging {
+		requireVault()
+	}
+
+	session := breathing.NewSession()
+	result, ok := session.StartHold(opts)
+	if !ok {
+		return
+	}
+
+	breathing.PrintHold(result)
+	if logging && len(result.Holds) > 0 {
+		kind := "hold"
+		if result.Bolt {
+			kind = "bolt"
+		}
+		recordPractice(practice.Entry{
+			Practice: kind,
+			Seconds:  int(result.Duration.Round(time.Second).Seconds()),
+			Holds:    practice.HoldSeconds(result.Holds),
+		})
+		showHoldTrend(kind)
+	}
+	breathing.AddBottomPadding()
+}
+
+// showHoldTrend shows the longest hold of the last few sessions, ending
+// with this one
+func showHoldTrend(kind string) {
+	entries, err := practice.Read(practice.LogPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+		return
+	}
+
+	trend := practice.HoldTrend(entries, kind, holdTrendLength)
+	if len(trend) < 2 {
+		return
+	}
+
+	scores := make([]string, len(trend))
+	for i, held := range trend {
+		scores[i] = fmt.Sprintf("%ds", int(held.Seconds()))
+	}
+	breathing.PrintWithPadding("   " + dim("Recent sessions: "+strings.Join(scores, " · ")))
+}
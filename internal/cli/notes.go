@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/config"
+	"github.com/e6a5/zenta/internal/practice"
+	"github.com/e6a5/zenta/internal/textwidth"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// defaultNotingLength is how long a noting sit lasts unless told otherwise
+const defaultNotingLength = 10 * time.Minute
+
+// HandleNotePractice handles the 'note-practice' command: a timed sit in
+// which single keys label the thoughts that arise. It reports whether the
+// sit lasted its full length.
+func HandleNotePractice(args []string) bool {
+	length := defaultNotingLength
+	for _, arg := range args {
+		d, err := time.ParseDuration(arg)
+		if err != nil || d <= 0 {
+			exitWithError("Invalid duration: %s (use a duration like 10m)", arg)
+		}
+		length = d
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+	labels := practice.DefaultLabels
+	if len(cfg.Notes.Keys) > 0 {
+		if labels, err = practice.ParseLabels(cfg.Notes.Keys); err != nil {
+			exitWithError("%s: %v", config.Path(), err)
+		}
+	}
+
+	if !tty.IsInteractive() {
+		exitWithError("note-practice needs a terminal to press keys in")
+	}
+	if cfg.Log.Enabled {
+		requireVault()
+	}
+
+	session := breathing.NewSession()
+	tally, elapsed := runNoting(session, labels, length)
+
+	printDistribution(tally, elapsed)
+	if cfg.Log.Enabled && tally.Total() > 0 {
+		recordPractice(practice.Entry{
+			Practice: "note-practice",
+			Seconds:  int(elapsed.Round(time.Second).Seconds()),
+			Notes:    tally,
+		})
+	}
+	breathing.AddBottomPadding()
+	return elapsed >= length
+}
+
+// runNoting sits for length, counting the labels noted, until the time is
+// up or the user finishes early
+func runNoting(session *breathing.Session, labels practice.Labels, length time.Duration) (practice.Tally, time.Duration) {
+	defer session.HideCursor()()
+
+	var legend []string
+	for _, key := range labels.Keys() {
+		legend = append(legend, fmt.Sprintf("[%c] %s", key, labels[key]))
+	}
+
+	fmt.Println()
+	breathing.PrintWithPadding("   🏷️")
+	breathing.PrintWithPadding("   Sit quietly. When a thought pulls you away, note it and return.")
+	breathing.PrintWithPadding("   " + strings.Join(legend, "  ") + "  ·  [q] to finish")
+	fmt.Println()
+
+	tally := practice.Tally{}
+	start := time.Now()
+
+	restore, err := tty.Raw()
+	if err != nil {
+		return tally, 0
+	}
+	defer restore()
+	tty.Drain()
+
+	keys := tty.Keys()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	deadline := time.NewTimer(length)
+	defer deadline.Stop()
+
+	drawNoting(tally, length)
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok || key == 'q' || key == 'Q' || key == tty.CtrlC {
+				fmt.Print("\r\033[K")
+				return tally, time.Since(start)
+			}
+			if label, found := labels[key]; found {
+				tally[label]++
+			}
+		case <-ticker.C:
+		case <-deadline.C:
+			fmt.Print("\r\033[K")
+			return tally, length
+		}
+		drawNoting(tally, length-time.Since(start))
+	}
+}
+
+// drawNoting shows the time left and a quiet running tally on one line
+func drawNoting(tally practice.Tally, left time.Duration) {
+	var counts []string
+	for _, c := range tally.Sorted() {
+		counts = append(counts, fmt.Sprintf("%s %d", c.Label, c.Count))
+	}
+	// Count down whole seconds, reaching 0:00 only as the sit ends
+	left = (left + time.Second - 1).Truncate(time.Second)
+	line := fmt.Sprintf("%s%-8s", strings.Repeat(" ", breathing.LeftPadding), formatClock(left))
+	if len(counts) > 0 {
+		line += " " + dim(strings.Join(counts, " · "))
+	}
+	fmt.Print("\r" + line + "\033[K")
+}
+
+// printDistribution shows how the noted thoughts were spread across labels
+func printDistribution(tally practice.Tally, elapsed time.Duration) {
+	total := tally.Total()
+	if total == 0 {
+		breathing.PrintWithPadding("   🌿 Nothing noted. A quiet sit.")
+		return
+	}
+
+	breathing.PrintWithPadding(fmt.Sprintf("   📊 What you noticed in %s:", formatClock(elapsed)))
+	sorted := tally.Sorted()
+	width := 0
+	for _, c := range sorted {
+		width = max(width, textwidth.String(c.Label))
+	}
+	const barWidth = 20
+	for _, c := range sorted {
+		n := max(1, c.Count*barWidth/sorted[0].Count)
+		bar := strings.Repeat("●", n) + strings.Repeat(" ", barWidth-n)
+		label := c.Label + strings.Repeat(" ", width-textwidth.String(c.Label))
+		breathing.PrintWithPadding(fmt.Sprintf("      %s  %s %d (%d%%)", label, bar, c.Count, c.Count*100/total))
+	}
+}
+
+// formatClock shows a duration as minutes and seconds, like 9:05
+func formatClock(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
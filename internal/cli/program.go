@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/guide"
+	"github.com/e6a5/zenta/internal/program"
+	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// HandleProgram handles the 'program' command: starting, following and
+// leaving multi-day practice programs
+func HandleProgram(args []string, programName string) {
+	usage := fmt.Sprintf("Usage: %s program start <name|file> | status | list | stop", programName)
+	if len(args) == 0 {
+		exitWithError("%s", usage)
+	}
+
+	switch args[0] {
+	case "start":
+		if len(args) != 2 {
+			exitWithError("Usage: %s program start <name|file>", programName)
+		}
+		startProgram(args[1], programName)
+	case "status":
+		showProgramStatus(programName)
+	case "list", "ls":
+		listPrograms()
+	case "stop":
+		leaveProgram()
+	default:
+		exitWithError("%s", usage)
+	}
+}
+
+// HandleToday handles the 'today' command: running the session the
+// current program day unlocks. With --again it repeats a day already done.
+func HandleToday(args []string, programName string) {
+	again := false
+	for _, arg := range args {
+		switch arg {
+		case "--again":
+			again = true
+		default:
+			exitWithError("Unknown option: %s", arg)
+		}
+	}
+
+	progress, p := currentProgram()
+	if progress == nil {
+		fmt.Printf("You're not following a program. Begin with '%s program start intro-7'.\n", programName)
+		return
+	}
+
+	now := time.Now()
+	if progress.DoneToday(now) {
+		if !again {
+			if progress.Finished(len(p.Days)) {
+				fmt.Printf("You finished %s today. 🙏\n", p.Title)
+			} else {
+				fmt.Printf("Day %d is done. Day %d, %s, unlocks tomorrow.\n", progress.Day()-1, progress.Day(), p.Days[progress.Day()-1].Title)
+			}
+			fmt.Printf("To practice today's session again: %s today --again\n", programName)
+			return
+		}
+		done := progress.Day() - 1
+		if done > len(p.Days) {
+			exitWithError("%s now has %d %s, fewer than the %d you've done; the program has changed since you began",
+				p.Title, len(p.Days), plural(len(p.Days), "day", "days"), done)
+		}
+		runProgramDay(p, done, programName)
+		return
+	}
+
+	if progress.Finished(len(p.Days)) {
+		fmt.Printf("You've completed %s. 🙏 Find another with '%s program list'.\n", p.Title, programName)
+		return
+	}
+
+	day := progress.Day()
+	if !runProgramDay(p, day, programName) {
+		breathing.PrintWithPadding(fmt.Sprintf("   Day %d isn't done yet. Take it up again with '%s today'.", day, programName))
+		fmt.Println()
+		return
+	}
+	progress.Complete(time.Now())
+	if err := progress.Save(programPath()); err != nil {
+		exitWithError("Saving your progress: %v", err)
+	}
+	breathing.PrintWithPadding(fmt.Sprintf("   ✓ Day %d of %d done.", day, len(p.Days)))
+	fmt.Println()
+}
+
+// runProgramDay introduces a day of a program and runs its session,
+// reporting whether the session finished
+func runProgramDay(p program.Program, day int, programName string) bool {
+	d := p.Days[day-1]
+	// The files a day names may have changed since the program began
+	if problems := dayProblems(d); len(problems) > 0 {
+		exitWithError("Day %d: %s", day, strings.Join(problems, "; "))
+	}
+	fmt.Println()
+	breathing.PrintWithPadding(fmt.Sprintf("%s · Day %d of %d", p.Title, day, len(p.Days)))
+	breathing.PrintWithPadding("   " + d.Title)
+	if d.Intro != "" {
+		breathing.PrintWithPadding("   " + dim(d.Intro))
+	}
+
+	args := d.Args()
+	switch args[0] {
+	case program.SessionNow:
+		return HandleNow(args[1:])
+	case program.SessionAnchor:
+		return HandleAnchor(args[1:])
+	case program.SessionCount:
+		return HandleCount(args[1:])
+	case program.SessionNotes:
+		return HandleNotePractice(args[1:])
+	case program.SessionSit:
+		return HandleSit(args[1:])
+	case program.SessionReflect:
+		fmt.Println()
+		return HandleReflect(args[1:])
+	case program.SessionGuide:
+		fmt.Println()
+		return HandleGuide(args[1:], programName)
+	}
+	return false
+}
+
+// startProgram enrolls in a program, leaving any other
+func startProgram(name, programName string) {
+	p, err := program.Find(name, programDir())
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	var problems []string
+	for i, d := range p.Days {
+		for _, problem := range dayProblems(d) {
+			problems = append(problems, fmt.Sprintf("day %d: %s", i+1, problem))
+		}
+	}
+	if len(problems) > 0 {
+		exitWithError("%s: %s", p.Name, strings.Join(problems, "; "))
+	}
+
+	if name != p.Name {
+		// Remember a file by its full path, so today works from anywhere
+		if abs, err := filepath.Abs(p.Source); err == nil {
+			name = abs
+		}
+	}
+
+	if current, _ := program.LoadProgress(programPath()); current != nil && current.Program != name {
+		fmt.Printf("Leaving '%s' after %d %s.\n", filepath.Base(current.Program), len(current.Completed), plural(len(current.Completed), "day", "days"))
+	}
+
+	progress := &program.Progress{Program: name, Started: time.Now()}
+	if err := progress.Save(programPath()); err != nil {
+		exitWithError("Saving your progress: %v", err)
+	}
+
+	fmt.Println(p.Title)
+	if p.Description != "" {
+		fmt.Println(p.Description)
+	}
+	fmt.Printf("%d days, one session a day. Begin now with '%s today'.\n", len(p.Days), programName)
+}
+
+// showProgramStatus lists the days of the current program and which are done
+func showProgramStatus(programName string) {
+	progress, p := currentProgram()
+	if progress == nil {
+		fmt.Printf("You're not following a program. See '%s program list'.\n", programName)
+		return
+	}
+
+	now := time.Now()
+	fmt.Printf("%s · %d of %d days done\n\n", p.Title, min(len(progress.Completed), len(p.Days)), len(p.Days))
+	for i, d := range p.Days {
+		day := i + 1
+		switch {
+		case day <= len(progress.Completed):
+			fmt.Printf("  ✓ Day %-3d %-28s %s\n", day, d.Title, dim(progress.Completed[i].Format("Mon Jan 2")))
+		case day == progress.Day() && progress.DoneToday(now):
+			fmt.Printf("  · Day %-3d %-28s %s\n", day, d.Title, dim("unlocks tomorrow"))
+		case day == progress.Day():
+			fmt.Printf("  → Day %-3d %-28s %s\n", day, d.Title, dim(programName+" today"))
+		default:
+			fmt.Printf("  · Day %-3d %s\n", day, d.Title)
+		}
+	}
+}
+
+// listPrograms prints the available programs
+func listPrograms() {
+	programs, errs := program.Available(programDir())
+	printLoadErrors(errs)
+	for _, p := range programs {
+		fmt.Printf("  %-12s %s  (%d days, %s)\n", p.Name, p.Title, len(p.Days), origin(p.Source))
+		if p.Description != "" {
+			fmt.Printf("  %-12s %s\n", "", dim(p.Description))
+		}
+	}
+}
+
+// dayProblems lists the patterns, prompt sets and guides a program day
+// names that can't be found
+func dayProblems(d program.Day) []string {
+	var problems []string
+	switch d.Session {
+	case program.SessionNow:
+		if d.Pattern != "" {
+			if _, err := breathing.FindPattern(d.Pattern, patternsPath()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	case program.SessionReflect:
+		if d.Set != "" {
+			if _, err := reflection.Find(d.Set, promptSetDir()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	case program.SessionGuide:
+		script, err := guide.Find(d.Guide, guideDir())
+		if err != nil {
+			problems = append(problems, err.Error())
+			break
+		}
+		problems = append(problems, scriptProblems(script)...)
+	}
+	return problems
+}
+
+// leaveProgram stops following the current program
+func leaveProgram() {
+	progress, _ := program.LoadProgress(programPath())
+	if progress == nil {
+		fmt.Println("You're not following a program.")
+		return
+	}
+	if err := program.Leave(programPath()); err != nil {
+		exitWithError("%v", err)
+	}
+	fmt.Printf("Left '%s'. What you practiced stays with you. 🙏\n", filepath.Base(progress.Program))
+}
+
+// currentProgram loads the program being followed and the progress
+// through it, or nil when there is none
+func currentProgram() (*program.Progress, program.Program) {
+	progress, err := program.LoadProgress(programPath())
+	if err != nil {
+		exitWithError("Reading your progress: %v", err)
+	}
+	if progress == nil {
+		return nil, program.Program{}
+	}
+
+	p, err := program.Find(progress.Program, programDir())
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	return progress, p
+}
+
+// programDir is where user programs live
+func programDir() string {
+	return filepath.Join(storage.ConfigDir(), "programs")
+}
+
+// programPath is where progress through the current program is kept
+func programPath() string {
+	return filepath.Join(storage.StateDir(), "program.json")
+}
@@ -0,0 +1,386 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/config"
+	"github.com/e6a5/zenta/internal/quotes"
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// HandleQuote handles the 'quote' command family: showing, listing,
+// searching, adding and favoriting quotes
+func HandleQuote(args []string, programName string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list", "ls":
+			handleQuoteList(args[1:])
+			return
+		case "search":
+			handleQuoteSearch(args[1:], programName)
+			return
+		case "add":
+			handleQuoteAdd(args[1:], programName)
+			return
+		case "fav":
+			handleQuoteFavorite(args[1:], true, programName)
+			return
+		case "unfav":
+			handleQuoteFavorite(args[1:], false, programName)
+			return
+		case "import":
+			handleQuoteImport(args[1:], programName)
+			return
+		}
+	}
+
+	handleQuoteShow(args)
+}
+
+// handleQuoteShow prints a single quote, typed out or plain
+func handleQuoteShow(args []string) {
+	var collection string
+	today := false
+	plain := false
+	opts := quoteDisplayOptions()
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--today", "-t":
+			today = true
+		case "--plain", "-p":
+			plain = true
+		case "--collection", "-c":
+			collection = requireValue(args, i)
+			i++
+		case "--style":
+			style, err := quotes.ParseStyle(requireValue(args, i))
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			opts.Style = style
+			i++
+		case "--speed":
+			speed, err := time.ParseDuration(requireValue(args, i))
+			if err != nil || speed < 0 {
+				exitWithError("Invalid speed: %s (use a duration like 20ms, or 0 for instant)", args[i+1])
+			}
+			opts.Speed = speed
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	quoteService := newQuoteService(collection)
+
+	var quote quotes.Quote
+	if today {
+		state, _ := quotes.LoadState(quoteStatePath())
+		quote = quoteService.QuoteOfTheDay(time.Now(), state)
+	} else {
+		quote = nextQuote(quoteService, "quote")
+	}
+
+	if plain {
+		fmt.Println(formatPlain(quote))
+		return
+	}
+	quotes.Display(quote, opts)
+}
+
+// displayQuote shows a quote using the display settings from the config file
+func displayQuote(quote quotes.Quote) {
+	quotes.Display(quote, quoteDisplayOptions())
+}
+
+// quoteDisplayOptions reads the quote style and speed from the config file,
+// warning about and ignoring settings it can't use
+func quoteDisplayOptions() quotes.DisplayOptions {
+	opts := quotes.DefaultDisplayOptions()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+		return opts
+	}
+
+	opts.Speed = cfg.Quote.Speed
+	style, err := quotes.ParseStyle(cfg.Quote.Style)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %s: %v\n", config.Path(), err)
+		return opts
+	}
+	opts.Style = style
+	return opts
+}
+
+// handleQuoteList prints every quote, grouped by collection
+func handleQuoteList(args []string) {
+	var collection string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--collection", "-c":
+			collection = requireValue(args, i)
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	quoteService := newQuoteService(collection)
+	state, _ := quotes.LoadState(quoteStatePath())
+
+	names := quoteService.Collections()
+	if collection != "" {
+		names = []string{collection}
+	}
+
+	for _, name := range names {
+		_ = quoteService.UseCollection(name)
+		fmt.Printf("%s (%d)\n", name, quoteService.QuoteCount())
+		for _, q := range quoteService.GetAllQuotes() {
+			printQuoteEntry(q, state)
+		}
+		fmt.Println()
+	}
+}
+
+// handleQuoteSearch prints quotes matching the search text
+func handleQuoteSearch(args []string, programName string) {
+	if len(args) == 0 {
+		exitWithError("Usage: %s quote search <text>", programName)
+	}
+
+	quoteService := newQuoteService("")
+	state, _ := quotes.LoadState(quoteStatePath())
+
+	matches := quoteService.Search(strings.Join(args, " "))
+	if len(matches) == 0 {
+		fmt.Println("No quotes found.")
+		return
+	}
+	for _, q := range matches {
+		printQuoteEntry(q, state)
+	}
+}
+
+// handleQuoteAdd appends a quote to the user's own collection
+func handleQuoteAdd(args []string, programName string) {
+	var quote quotes.Quote
+	var text []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--author", "-a":
+			quote.Author = requireValue(args, i)
+			i++
+		case "--source":
+			quote.Source = requireValue(args, i)
+			i++
+		case "--emoji":
+			quote.Emoji = requireValue(args, i)
+			i++
+		case "--tags":
+			for _, tag := range strings.Split(requireValue(args, i), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					quote.Tags = append(quote.Tags, tag)
+				}
+			}
+			i++
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				exitWithError("Unknown option: %s", args[i])
+			}
+			text = append(text, args[i])
+		}
+	}
+
+	quote.Text = strings.TrimSpace(strings.Join(text, " "))
+	if quote.Text == "" {
+		exitWithError("Usage: %s quote add \"text\" [--author NAME] [--tags a,b] [--emoji E] [--source WORK]", programName)
+	}
+
+	service := newQuoteService("")
+	if existing, _, err := service.Find(quote.Key()); err == nil {
+		collection, builtin := service.Origin(existing)
+		where := fmt.Sprintf("your '%s' collection", collection)
+		if builtin {
+			where = fmt.Sprintf("the built-in '%s' collection", collection)
+		}
+		exitWithError("That quote is already in %s: %s", where, formatPlain(existing))
+	}
+
+	path := filepath.Join(storage.ConfigDir(), "quotes", quotes.UserCollection+".yaml")
+	if err := quotes.AppendQuote(path, quote); err != nil {
+		exitWithError("Error adding quote: %v", err)
+	}
+	fmt.Printf("Added to '%s' [%s] 🌱\n", quotes.UserCollection, quote.Key())
+}
+
+// handleQuoteFavorite marks or unmarks a quote, found by id or text, as a favorite
+func handleQuoteFavorite(args []string, favorite bool, programName string) {
+	if len(args) == 0 {
+		exitWithError("Usage: %s quote fav|unfav <id or text>", programName)
+	}
+
+	path := quoteStatePath()
+	state, err := quotes.LoadState(path)
+	if err != nil {
+		exitWithError("Error reading quote state: %v", err)
+	}
+
+	quote, candidates, err := newQuoteService("").Find(strings.Join(args, " "))
+	if err != nil {
+		for _, q := range candidates {
+			printQuoteEntry(q, state)
+		}
+		exitWithError("Error: %v", err)
+	}
+
+	if state.SetFavorite(quote, favorite) {
+		if err := state.Save(path); err != nil {
+			exitWithError("Error saving favorites: %v", err)
+		}
+	}
+
+	if favorite {
+		fmt.Printf("★ %s\n", formatPlain(quote))
+	} else {
+		fmt.Printf("  %s\n", formatPlain(quote))
+	}
+}
+
+// handleQuoteImport imports quotes from a fortune database or a plain
+// text file into a named collection
+func handleQuoteImport(args []string, programName string) {
+	var fortunePath, indexPath, linesPath, into string
+	maxLines := quotes.DefaultImportLines
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--fortune":
+			fortunePath = requireValue(args, i)
+			i++
+		case "--dat":
+			indexPath = requireValue(args, i)
+			i++
+		case "--lines":
+			linesPath = requireValue(args, i)
+			i++
+		case "--into":
+			into = requireValue(args, i)
+			i++
+		case "--max-lines":
+			maxLines = parseCount(args[i], requireValue(args, i))
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	if (fortunePath == "") == (linesPath == "") {
+		exitWithError("Usage: %s quote import --fortune PATH [--dat PATH] | --lines PATH [--into NAME] [--max-lines N]", programName)
+	}
+
+	source := fortunePath + linesPath
+	// #nosec G304 -- importing a file the user named
+	data, err := os.ReadFile(source)
+	if err != nil {
+		exitWithError("Error reading %s: %v", source, err)
+	}
+
+	var candidates []quotes.Quote
+	if fortunePath != "" {
+		// Use the strfile index when present beside the database
+		if indexPath == "" {
+			if _, err := os.Stat(fortunePath + ".dat"); err == nil {
+				indexPath = fortunePath + ".dat"
+			}
+		}
+		var index []byte
+		if indexPath != "" {
+			// #nosec G304 -- importing a file the user named
+			if index, err = os.ReadFile(indexPath); err != nil {
+				exitWithError("Error reading %s: %v", indexPath, err)
+			}
+		}
+		if candidates, err = quotes.ParseFortune(data, index); err != nil {
+			exitWithError("Error parsing %s: %v", indexPath, err)
+		}
+	} else {
+		candidates = quotes.ParseLines(data)
+	}
+
+	if into == "" {
+		into = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+	if !validName(into) {
+		exitWithError("Invalid collection name: %s (use letters, digits, - and _, or choose one with --into)", into)
+	}
+
+	result := newQuoteService("").FilterImport(candidates, maxLines)
+	if len(result.Added) > 0 {
+		path := filepath.Join(storage.ConfigDir(), "quotes", into+".yaml")
+		if err := quotes.AppendQuotes(path, result.Added); err != nil {
+			exitWithError("Error writing %s: %v", path, err)
+		}
+	}
+
+	fmt.Printf("Imported %d quotes into '%s'", len(result.Added), into)
+	fmt.Printf(" (skipped %d duplicates, %d too long, %d empty)\n", result.Duplicates, result.TooLong, result.Empty)
+}
+
+// printQuoteEntry prints one line of a quote listing, starring favorites
+func printQuoteEntry(q quotes.Quote, state *quotes.State) {
+	marker := " "
+	if state.IsFavorite(q) {
+		marker = "★"
+	}
+	fmt.Printf("  %s [%s] %s %s\n", marker, q.Key(), q.DisplayEmoji(), formatPlain(q))
+}
+
+// formatPlain renders a quote on one line without animation
+func formatPlain(q quotes.Quote) string {
+	if attribution := q.Attribution(); attribution != "" {
+		return q.Text + " — " + attribution
+	}
+	return q.Text
+}
+
+// quoteStatePath returns where quote rotation and favorites are kept
+func quoteStatePath() string {
+	return filepath.Join(storage.StateDir(), "quotes.json")
+}
+
+// nextQuote walks the persisted shuffle of the active collection, so
+// quotes don't repeat until every one has been shown, favoring quotes that
+// suit the time of day and the session that just ended. If the state can't
+// be read it falls back to a random quote.
+func nextQuote(quoteService *quotes.QuoteService, session string) quotes.Quote {
+	path := quoteStatePath()
+	state, err := quotes.LoadState(path)
+	if err != nil {
+		return quoteService.GetRandomQuote()
+	}
+
+	rules, err := quotes.LoadRules(filepath.Join(storage.ConfigDir(), "quote-rules.yaml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+
+	ctx := quotes.Context{Time: time.Now(), Session: session}
+	// #nosec G404 -- shuffling quotes needs no cryptographic randomness
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	quote := quoteService.NextInRotation(state, rng, ctx, rules)
+
+	if err := state.Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: could not save quote rotation: %v\n", err)
+	}
+	return quote
+}
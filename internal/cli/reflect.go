@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/config"
+	"github.com/e6a5/zenta/internal/guide"
+	"github.com/e6a5/zenta/internal/practice"
+	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/storage"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// Line prefixes for each section of a reflection
+const (
+	linePrefix   = "   "
+	promptPrefix = "      • "
+	answerPrefix = "        › "
+)
+
+// HandleReflect handles the 'reflect' command for mindful reflection. It
+// reports whether a reflection session ran to its end.
+func HandleReflect(args []string) bool {
+	setName := reflection.DefaultSet
+	autoAdvance := reflectConfig().AutoAdvance
+	write := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--set":
+			setName = requireValue(args, i)
+			i++
+		case "--list", "-l":
+			listPromptSets()
+			return false
+		case "--write", "-w":
+			write = true
+		case "--review":
+			// The date is optional
+			var date string
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				date = args[i+1]
+			}
+			reviewReflections(date)
+			return false
+		case "--auto-advance":
+			d, err := time.ParseDuration(requireValue(args, i))
+			if err != nil || d < 0 {
+				exitWithError("Invalid duration: %s (use a duration like 45s, or 0 to wait for a key)", args[i+1])
+			}
+			autoAdvance = d
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	prompts, err := reflection.Find(setName, promptSetDir())
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	if write && !tty.IsInteractive() {
+		exitWithError("reflect --write needs a terminal to type in")
+	}
+	if write {
+		requireVault()
+	}
+
+	if summary := notingSummary(); summary != "" {
+		prompts.Instructions = append(prompts.Instructions, reflection.Line{Text: "🏷️  " + summary, Pause: reflection.InstructionPause})
+	}
+
+	r := &guideSession{out: os.Stdout, autoAdvance: autoAdvance, write: write}
+	defer r.attach()()
+
+	start := time.Now()
+	finished := r.run(guide.FromPromptSet(prompts.Name, prompts))
+
+	if write {
+		saveAnswers(r.out, start, prompts, r.answers)
+	}
+	return finished
+}
+
+// showLines prints lines, each followed by its pause
+func (r *guideSession) showLines(lines []reflection.Line, prefix string) bool {
+	for _, line := range lines {
+		r.println(prefix + line.Text)
+		if !r.pause(line.Pause) {
+			return false
+		}
+	}
+	return true
+}
+
+// showPrompts walks through the prompts. Interactively, each waits for a
+// key (or the auto-advance timeout) and the user may step back; each
+// prompt is redrawn in place with a progress indicator.
+func (r *guideSession) showPrompts(prompts []reflection.Line) bool {
+	if r.keys == nil {
+		return r.showLines(prompts, promptPrefix)
+	}
+	if r.write {
+		return r.writePrompts(prompts)
+	}
+
+	for i := 0; i < len(prompts); {
+		if r.keys == nil {
+			return r.showLines(prompts[i:], promptPrefix) // Input closed; fall back to timed pauses
+		}
+		r.drawPrompt(prompts[i], i, len(prompts))
+
+		key, pressed := r.waitForKey(r.autoAdvance)
+		action := advancePrompt
+		if pressed {
+			action = promptKeyAction(key)
+		}
+
+		switch action {
+		case advancePrompt:
+			// Leave the prompt on screen without its progress indicator
+			r.drawPrompt(prompts[i], -1, len(prompts))
+			fmt.Fprintln(r.out)
+			i++
+		case previousPrompt:
+			if i > 0 {
+				fmt.Fprint(r.out, "\r\033[K\033[A")
+				i--
+			}
+		case quitReflection:
+			fmt.Fprintln(r.out)
+			return false
+		}
+	}
+	return true
+}
+
+// writePrompts shows each prompt with a line editor beneath it. Enter
+// saves the answer and moves on; the up arrow returns to the previous
+// prompt to revise its answer.
+func (r *guideSession) writePrompts(prompts []reflection.Line) bool {
+	r.answers = make([]string, len(prompts))
+
+	for i := 0; i < len(prompts); {
+		r.drawPrompt(prompts[i], i, len(prompts))
+		fmt.Fprintf(r.out, "\n%s%s", strings.Repeat(" ", breathing.LeftPadding), answerPrefix)
+
+		text, result := tty.ReadLine(r.keys, r.out, r.answers[i])
+		r.answers[i] = text
+
+		switch result {
+		case tty.LineEntered:
+			// Step up to drop the progress indicator, then below the answer
+			fmt.Fprint(r.out, "\033[A")
+			r.drawPrompt(prompts[i], -1, len(prompts))
+			fmt.Fprint(r.out, "\n\n")
+			i++
+		case tty.LineBack:
+			if i > 0 {
+				// Clear this prompt and the previous answer, then redraw
+				// the previous prompt in their place
+				fmt.Fprint(r.out, "\r\033[K", strings.Repeat("\033[A\r\033[K", 3))
+				i--
+			} else {
+				fmt.Fprint(r.out, "\r\033[K\033[A")
+			}
+		case tty.LineCancelled:
+			fmt.Fprintln(r.out)
+			return false
+		}
+	}
+	return true
+}
+
+// drawPrompt redraws the current line with a prompt and, for index >= 0,
+// a dim progress indicator like "2/3"
+func (r *guideSession) drawPrompt(prompt reflection.Line, index, count int) {
+	line := promptPrefix + prompt.Text
+	if index >= 0 {
+		line += "  " + dim(fmt.Sprintf("%d/%d", index+1, count))
+	}
+	fmt.Fprintf(r.out, "\r\033[K%s%s", strings.Repeat(" ", breathing.LeftPadding), line)
+}
+
+// pause waits for d. Interactively any key ends the pause early; it
+// returns false if that key asked to quit.
+func (r *guideSession) pause(d time.Duration) bool {
+	if r.keys == nil {
+		time.Sleep(d)
+		return true
+	}
+	if d <= 0 {
+		return true
+	}
+	key, pressed := r.waitForKey(d)
+	return !pressed || promptKeyAction(key) != quitReflection
+}
+
+// waitForKey waits up to timeout for a key press, or indefinitely when
+// timeout is zero. It reports false when no key was pressed.
+func (r *guideSession) waitForKey(timeout time.Duration) (byte, bool) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case key, ok := <-r.keys:
+		if !ok {
+			r.keys = nil // Input closed; nothing more to wait for
+		}
+		return key, ok
+	case <-expired:
+		return 0, false
+	}
+}
+
+// println prints a padded line of the reflection
+func (r *guideSession) println(text string) {
+	fmt.Fprintf(r.out, "%s%s\n", strings.Repeat(" ", breathing.LeftPadding), text)
+}
+
+// blank prints n empty lines
+func (r *guideSession) blank(n int) {
+	fmt.Fprint(r.out, strings.Repeat("\n", n))
+}
+
+// promptAction is what a key press does to the reflection
+type promptAction int
+
+const (
+	ignoreKey promptAction = iota
+	advancePrompt
+	previousPrompt
+	quitReflection
+)
+
+// promptKeyAction maps a key press to its action
+func promptKeyAction(key byte) promptAction {
+	switch key {
+	case ' ', tty.Enter, '\n':
+		return advancePrompt
+	case 'b', 'B':
+		return previousPrompt
+	case 'q', 'Q', tty.CtrlC:
+		return quitReflection
+	}
+	return ignoreKey
+}
+
+// dim renders text in the terminal's faint style
+func dim(text string) string {
+	return "\033[2m" + text + "\033[0m"
+}
+
+// saveAnswers appends the written answers to today's reflection journal
+func saveAnswers(w io.Writer, when time.Time, prompts reflection.PromptSet, texts []string) {
+	answers := make([]reflection.Answer, len(texts))
+	for i, text := range texts {
+		answers[i] = reflection.Answer{Prompt: prompts.Prompts[i].Text, Text: text}
+	}
+
+	path, err := reflection.SaveEntry(journalDir(), when, prompts.Title, answers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: saving your answers: %v\n", err)
+		return
+	}
+	if path != "" {
+		fmt.Fprintf(w, "%s%s\n\n", strings.Repeat(" ", breathing.LeftPadding), dim("Kept privately in "+path))
+	}
+}
+
+// reviewReflections prints the answers written on a day: "today",
+// "yesterday" or YYYY-MM-DD. Without a date it shows the latest day.
+func reviewReflections(date string) {
+	dir := journalDir()
+
+	var day time.Time
+	switch date {
+	case "":
+		days, err := reflection.JournalDays(dir)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		if len(days) == 0 {
+			fmt.Println("No written reflections yet. Try 'zenta reflect --write'.")
+			return
+		}
+		day = days[len(days)-1]
+	case "today":
+		day = time.Now()
+	case "yesterday":
+		day = time.Now().AddDate(0, 0, -1)
+	default:
+		var err error
+		day, err = time.ParseInLocation(reflection.DateLayout, date, time.Local)
+		if err != nil {
+			exitWithError("Invalid date: %s (use YYYY-MM-DD, today or yesterday)", date)
+		}
+	}
+
+	requireVault()
+	data, found, err := reflection.ReadJournal(dir, day)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	if !found {
+		fmt.Printf("No written reflections on %s.\n", day.Format(reflection.DateLayout))
+		return
+	}
+	fmt.Print(string(data))
+}
+
+// journalDir is where written reflections are kept
+func journalDir() string {
+	return filepath.Join(storage.DataDir(), "reflections")
+}
+
+// reflectConfig reads the reflection settings from the config file
+func reflectConfig() config.ReflectConfig {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+	return cfg.Reflect
+}
+
+// notingSummary describes the past week of noting practice from the
+// practice log, or returns "" when there's nothing to say or no log to read
+func notingSummary() string {
+	if !practiceLogging() {
+		return ""
+	}
+	entries, err := practice.Read(practice.LogPath())
+	if err != nil {
+		return ""
+	}
+	return practice.WeekSummary(practice.WeekNotes(entries, time.Now()))
+}
+
+// promptSetDir is where user reflection prompt sets live
+func promptSetDir() string {
+	return filepath.Join(storage.ConfigDir(), "reflect")
+}
+
+// listPromptSets prints the available reflection prompt sets
+func listPromptSets() {
+	sets, errs := reflection.Available(promptSetDir())
+	printLoadErrors(errs)
+	for _, set := range sets {
+		fmt.Printf("  %-10s %s  (%d prompts, %s)\n", set.Name, strings.TrimSpace(set.Title), len(set.Prompts), origin(set.Source))
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/e6a5/zenta/internal/guide"
+	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+func TestPromptKeyAction(t *testing.T) {
+	testCases := []struct {
+		key  byte
+		want promptAction
+	}{
+		{' ', advancePrompt},
+		{tty.Enter, advancePrompt},
+		{'b', previousPrompt},
+		{'q', quitReflection},
+		{tty.CtrlC, quitReflection},
+		{'x', ignoreKey},
+	}
+
+	for _, tc := range testCases {
+		if got := promptKeyAction(tc.key); got != tc.want {
+			t.Errorf("promptKeyAction(%q) = %v, want %v", tc.key, got, tc.want)
+		}
+	}
+}
+
+func testPrompts() []reflection.Line {
+	return []reflection.Line{{Text: "First?"}, {Text: "Second?"}, {Text: "Third?"}}
+}
+
+func TestShowPromptsSelfPaced(t *testing.T) {
+	keys := make(chan byte, 8)
+	for _, key := range []byte{' ', 'b', 'x', ' ', ' ', tty.Enter} {
+		keys <- key
+	}
+
+	var out bytes.Buffer
+	r := &guideSession{out: &out, keys: keys}
+
+	if !r.showPrompts(testPrompts()) {
+		t.Fatal("Expected the prompts to finish")
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected every key to be used, %d left", len(keys))
+	}
+
+	output := out.String()
+	for _, want := range []string{"First?", "Second?", "Third?", "2/3", "3/3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output %q", want, output)
+		}
+	}
+	if !strings.Contains(output, "\033[A") {
+		t.Error("Expected 'b' to move back to the previous prompt")
+	}
+}
+
+func TestShowPromptsQuit(t *testing.T) {
+	keys := make(chan byte, 2)
+	keys <- ' '
+	keys <- 'q'
+
+	r := &guideSession{out: &bytes.Buffer{}, keys: keys}
+	if r.showPrompts(testPrompts()) {
+		t.Error("Expected 'q' to end the reflection")
+	}
+}
+
+func TestShowPromptsAutoAdvance(t *testing.T) {
+	var out bytes.Buffer
+	r := &guideSession{out: &out, keys: make(chan byte), autoAdvance: time.Millisecond}
+
+	if !r.showPrompts(testPrompts()) {
+		t.Fatal("Expected the prompts to advance on their own")
+	}
+	if !strings.Contains(out.String(), "Third?") {
+		t.Errorf("Expected every prompt, got %q", out.String())
+	}
+}
+
+func TestReflectWithoutTerminal(t *testing.T) {
+	set := reflection.PromptSet{
+		Title:        "Test",
+		Instructions: []reflection.Line{{Text: "Breathe."}},
+		Prompts:      testPrompts(),
+		Closing:      []reflection.Line{{Text: "Done."}},
+	}
+
+	var out bytes.Buffer
+	r := &guideSession{out: &out}
+	start := time.Now()
+	r.run(guide.FromPromptSet("test", set))
+
+	if time.Since(start) < reflection.TitlePause {
+		t.Error("Expected timed pauses without a terminal")
+	}
+	if !strings.Contains(out.String(), promptPrefix+"Second?\n") || strings.Contains(out.String(), "2/3") {
+		t.Errorf("Expected plain prompts without progress, got %q", out.String())
+	}
+}
+
+func TestWritePrompts(t *testing.T) {
+	keys := make(chan byte, 64)
+	// Answer the first, revise it after going back, then answer the rest
+	for _, key := range []byte("busy\r\x1b[A day\r\rcalm\r") {
+		keys <- key
+	}
+
+	var out bytes.Buffer
+	r := &guideSession{out: &out, keys: keys, write: true}
+
+	if !r.writePrompts(testPrompts()) {
+		t.Fatal("Expected the prompts to finish")
+	}
+
+	want := []string{"busy day", "", "calm"}
+	for i, answer := range r.answers {
+		if answer != want[i] {
+			t.Errorf("Answer %d = %q, want %q", i+1, answer, want[i])
+		}
+	}
+}
+
+func TestQuoteSessionAfterPrompts(t *testing.T) {
+	r := &guideSession{out: &bytes.Buffer{}, keys: make(chan byte), autoAdvance: time.Millisecond}
+	if got := r.quoteSession(); got != "guide" {
+		t.Errorf("Expected %q before any prompts, got %q", "guide", got)
+	}
+
+	r.runStep(guide.Step{Kind: guide.StepPrompts, Prompts: testPrompts()})
+	if got := r.quoteSession(); got != "reflect" {
+		t.Errorf("Expected %q after the prompts, got %q", "reflect", got)
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/shellhook"
+)
+
+// HandleShellInit prints prompt hook code for the requested shell,
+// meant to be evaluated from the user's shell configuration.
+func HandleShellInit(args []string, programName string) {
+	if len(args) == 0 {
+		exitWithError("Usage: %s shell-init bash|zsh|fish [--after-failures N] [--after DURATION]", programName)
+	}
+
+	shell := args[0]
+	opts := parseHookOptions(args[1:])
+
+	script, err := shellhook.Script(shell, programName, opts)
+	if err != nil {
+		exitWithError("Error: %v", err)
+	}
+	fmt.Print(script)
+}
+
+// HandleShellNudge evaluates the prompt hook heuristics for the command that
+// just finished. It prints an invitation and exits 0 when a breath is
+// suggested, and exits 1 silently otherwise.
+func HandleShellNudge(args []string) {
+	var stats shellhook.Stats
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--status":
+			stats.Status = parseCount(args[i], requireValue(args, i))
+			i++
+		case "--elapsed":
+			stats.Elapsed = time.Duration(parseCount(args[i], requireValue(args, i))) * time.Second
+			i++
+		case "--failures":
+			stats.Failures = parseCount(args[i], requireValue(args, i))
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	message, ok := shellhook.Evaluate(stats, parseHookOptions(rest))
+	if !ok {
+		os.Exit(1)
+	}
+	breathing.PrintWithPadding(message)
+}
+
+// parseHookOptions parses the heuristic thresholds shared by shell-init and shell-nudge
+func parseHookOptions(args []string) shellhook.Options {
+	opts := shellhook.DefaultOptions()
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--after-failures":
+			opts.Failures = parseCount(args[i], requireValue(args, i))
+			i++
+		case "--after":
+			d, err := time.ParseDuration(requireValue(args, i))
+			if err != nil {
+				exitWithError("Invalid duration for --after: %v", err)
+			}
+			opts.LongCommand = d
+			i++
+		default:
+			exitWithError("Unknown option: %s", args[i])
+		}
+	}
+
+	return opts
+}
+
+// parseCount parses a non-negative integer flag value
+func parseCount(flag, value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		exitWithError("Invalid value for %s: %s", flag, value)
+	}
+	return n
+}
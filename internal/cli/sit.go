@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/breathing"
+	"github.com/e6a5/zenta/internal/config"
+	"github.com/e6a5/zenta/internal/practice"
+	"github.com/e6a5/zenta/internal/sit"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// defaultSitLength is how long a sit lasts unless told otherwise
+const defaultSitLength = 20 * time.Minute
+
+// sitProgressWidth is the width of the dim progress line, in dots
+const sitProgressWidth = 30
+
+// sitOptions shape a sit
+type sitOptions struct {
+	length   time.Duration
+	interval time.Duration // Between interval bells; 0 for none
+	prepare  time.Duration // Countdown before the start bell
+	progress bool
+	bell     sit.Bell
+}
+
+// HandleSit handles the 'sit' command: a silent meditation timer with
+// bells at the start, at intervals and at the end. It reports whether the
+// sit lasted its full length.
+func HandleSit(args []string) bool {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zenta: %v\n", err)
+	}
+	opts := sitOptions{
+		length:   defaultSitLength,
+		prepare:  cfg.Sit.Prepare,
+		progress: cfg.Sit.Progress,
+		bell:     sit.Bell{Style: cfg.Sit.Bell, Command: cfg.Sit.BellCommand},
+	}
+	if _, err := sit.ParseBellStyle(opts.bell.Style); err != nil {
+		exitWithError("%s: %v", config.Path(), err)
+	}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--interval", "-i":
+			opts.interval = parseSitDuration(requireValue(args, i))
+			i++
+		case "--prepare":
+			d, err := time.ParseDuration(requireValue(args, i))
+			if err != nil || d < 0 {
+				exitWithError("Invalid duration: %s", args[i+1])
+			}
+			opts.prepare = d
+			i++
+		case "--bell":
+			style, err := sit.ParseBellStyle(requireValue(args, i))
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			// Choosing a bell here overrides a configured command
+			opts.bell = sit.Bell{Style: style}
+			i++
+		case "--no-progress":
+			opts.progress = false
+		case "--progress":
+			opts.progress = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				exitWithError("Unknown option: %s", args[i])
+			}
+			opts.length = parseSitDuration(args[i])
+		}
+	}
+
+	if !tty.IsInteractive() {
+		exitWithError("sit needs a terminal")
+	}
+	logging := cfg.Log.Enabled
+	if logging {
+		requireVault()
+	}
+
+	session := breathing.NewSession()
+	sat, bellErr, err := runSit(session, opts)
+	if err != nil {
+		breathing.PrintWithPadding("Error: This terminal does not support this mode.")
+		breathing.PrintWithPadding("The 'zenta now' command is a great alternative.")
+		fmt.Println()
+		return false
+	}
+
+	if bellErr != nil {
+		fmt.Fprintf(os.Stderr, "zenta: the bell could not ring: %v\n", bellErr)
+	}
+	if sat > 0 {
+		breathing.PrintWithPadding(fmt.Sprintf("   🙏 Sat for %s.", formatClock(sat)))
+		if logging {
+			recordPractice(practice.Entry{Practice: "sit", Seconds: int(sat.Round(time.Second).Seconds())})
+		}
+	}
+	breathing.AddBottomPadding()
+	return sat >= opts.length
+}
+
+// parseSitDuration reads a positive duration like 20m
+func parseSitDuration(value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		exitWithError("Invalid duration: %s (use a duration like 20m)", value)
+	}
+	return d
+}
+
+// runSit clears the screen and sits, returning how long was sat, the
+// first error from the bell, and any error setting up the terminal. Space
+// pauses and resumes; q ends early.
+func runSit(session *breathing.Session, opts sitOptions) (time.Duration, error, error) {
+	defer session.HideCursor()()
+
+	restore, err := tty.Raw()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer restore()
+	tty.Drain()
+
+	out := tty.NewlineWriter(os.Stdout)
+	keys := tty.Keys()
+	fmt.Fprint(out, "\033[2J\033[H\n\n\n")
+
+	var bellErr error
+	ring := func() {
+		if err := opts.bell.Ring(out); err != nil && bellErr == nil {
+			bellErr = err
+		}
+	}
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	// Settle in before the start bell; space starts at once
+	for end := time.Now().Add(opts.prepare); time.Now().Before(end); {
+		left := (time.Until(end) + time.Second - 1).Truncate(time.Second)
+		fmt.Fprintf(out, "\r%s%s\033[K", strings.Repeat(" ", breathing.LeftPadding), dim(fmt.Sprintf("settling in · %d", int(left.Seconds()))))
+		select {
+		case key, ok := <-keys:
+			if !ok || key == 'q' || key == 'Q' || key == tty.CtrlC {
+				fmt.Fprint(out, "\r\033[K")
+				return 0, bellErr, nil
+			}
+			if key == ' ' {
+				end = time.Now()
+			}
+		case <-ticker.C:
+		}
+	}
+
+	ring()
+	timer := sit.NewTimer(opts.length, time.Now())
+	var rung time.Duration // How far into the sit the bells have kept up to
+	for {
+		now := time.Now()
+		elapsed := timer.Elapsed(now)
+		for i := sit.IntervalsCrossed(rung, elapsed, opts.interval, opts.length); i > 0; i-- {
+			ring()
+		}
+		rung = elapsed
+
+		if timer.Done(now) {
+			fmt.Fprint(out, "\r\033[K")
+			ring()
+			return elapsed, bellErr, nil
+		}
+		drawSit(out, timer, now, opts.progress)
+
+		select {
+		case key, ok := <-keys:
+			if !ok || key == 'q' || key == 'Q' || key == tty.CtrlC {
+				fmt.Fprint(out, "\r\033[K")
+				return timer.Elapsed(time.Now()), bellErr, nil
+			}
+			if key == ' ' {
+				timer.Toggle(time.Now())
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// drawSit shows a dim line of progress and the time left, or only that
+// the sit is paused when progress is hidden
+func drawSit(w io.Writer, timer *sit.Timer, now time.Time, progress bool) {
+	var line string
+	if progress {
+		filled := int(timer.Elapsed(now) * sitProgressWidth / timer.Length)
+		left := (timer.Remaining(now) + time.Second - 1).Truncate(time.Second)
+		line = strings.Repeat("●", filled) + strings.Repeat("·", sitProgressWidth-filled) + "  " + formatClock(left)
+	}
+	if timer.Paused() {
+		line = strings.TrimSpace(line + "  paused · space to resume")
+	}
+	fmt.Fprintf(w, "\r%s%s\033[K", strings.Repeat(" ", breathing.LeftPadding), dim(line))
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// MinPassphraseLength is the shortest passphrase accepted for a new vault
+const MinPassphraseLength = 8
+
+// HandleVault handles the 'vault' command family for encrypting local data
+func HandleVault(args []string, programName string) {
+	if len(args) == 0 {
+		exitWithError("Usage: %s vault lock|unlock|rekey|status", programName)
+	}
+
+	switch args[0] {
+	case "lock":
+		handleVaultLock()
+	case "unlock":
+		handleVaultUnlock()
+	case "rekey":
+		handleVaultRekey()
+	case "status":
+		handleVaultStatus()
+	default:
+		exitWithError("Unknown vault command: %s (use lock, unlock, rekey or status)", args[0])
+	}
+}
+
+// handleVaultLock creates the vault on first use, and otherwise encrypts
+// anything left in plain text and forgets the key
+func handleVaultLock() {
+	if !storage.VaultEnabled() {
+		fmt.Println("Creating a vault for your reflections and records.")
+		fmt.Println("There is no way to recover them without this passphrase.")
+		passphrase := readNewPassphrase()
+
+		count, err := storage.CreateVault(passphrase)
+		if err != nil {
+			exitWithError("Creating the vault: %v", err)
+		}
+		fmt.Printf("🔒 Vault locked. %d %s encrypted.\n", count, plural(count, "file", "files"))
+		return
+	}
+
+	count, err := storage.LockVault()
+	if errors.Is(err, storage.ErrLocked) {
+		fmt.Println("🔒 The vault is already locked.")
+		return
+	}
+	if err != nil {
+		exitWithError("Locking the vault: %v", err)
+	}
+	fmt.Print("🔒 Vault locked.")
+	if count > 0 {
+		fmt.Printf(" %d more %s encrypted.", count, plural(count, "file", "files"))
+	}
+	fmt.Println()
+}
+
+// handleVaultUnlock keeps the key until the vault is locked or the user
+// logs out
+func handleVaultUnlock() {
+	if !storage.VaultEnabled() {
+		exitWithError("%v", storage.ErrNoVault)
+	}
+	if storage.VaultUnlocked() {
+		fmt.Println("🔓 The vault is already unlocked.")
+		return
+	}
+
+	err := storage.UnlockVault(readPassphrase("Passphrase: "))
+	if errors.Is(err, storage.ErrNoKeyCache) {
+		fmt.Printf("🔓 The passphrase is right, but %v.\n", err)
+		fmt.Println("   Each command that needs the vault will ask for it instead.")
+		return
+	}
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	fmt.Println("🔓 Vault unlocked until you lock it or log out.")
+}
+
+// requireVault makes sure the vault key is at hand before a command reads
+// or writes its data. Where the key can't be kept between commands, it
+// asks for the passphrase.
+func requireVault() {
+	if !storage.VaultEnabled() || storage.VaultUnlocked() {
+		return
+	}
+	if storage.CanKeepKey() {
+		exitWithError("%v", storage.ErrLocked)
+	}
+	err := storage.UnlockVault(readPassphrase("Vault passphrase: "))
+	if err != nil && !errors.Is(err, storage.ErrNoKeyCache) {
+		exitWithError("%v", err)
+	}
+}
+
+// handleVaultRekey changes the vault passphrase
+func handleVaultRekey() {
+	if !storage.VaultEnabled() {
+		exitWithError("%v", storage.ErrNoVault)
+	}
+
+	current := readPassphrase("Current passphrase: ")
+	next := readNewPassphrase()
+	if err := storage.RekeyVault(current, next); err != nil {
+		exitWithError("%v", err)
+	}
+	fmt.Println("🔑 Passphrase changed.")
+}
+
+// handleVaultStatus reports whether the vault exists and is unlocked
+func handleVaultStatus() {
+	switch {
+	case !storage.VaultEnabled():
+		fmt.Println("No vault: data files are stored in plain text.")
+	case storage.VaultUnlocked():
+		fmt.Println("🔓 Vault unlocked.")
+	default:
+		fmt.Println("🔒 Vault locked.")
+	}
+}
+
+// readNewPassphrase asks for a passphrase twice
+func readNewPassphrase() []byte {
+	passphrase := readPassphrase("New passphrase: ")
+	if len(passphrase) < MinPassphraseLength {
+		exitWithError("The passphrase must be at least %d characters", MinPassphraseLength)
+	}
+	if !bytes.Equal(passphrase, readPassphrase("Repeat passphrase: ")) {
+		exitWithError("The passphrases don't match")
+	}
+	return passphrase
+}
+
+// readPassphrase reads a passphrase from the terminal without echoing it
+func readPassphrase(prompt string) []byte {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		exitWithError("The vault needs a terminal to read the passphrase")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		exitWithError("Reading the passphrase: %v", err)
+	}
+	return passphrase
+}
+
+// plural picks the singular or plural form for n
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
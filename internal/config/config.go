@@ -0,0 +1,123 @@
+// Package config loads zenta's optional configuration file.
+// Everything has a sensible default; the file at
+// $XDG_CONFIG_HOME/zenta/config.yaml only needs the settings a user wants
+// to change.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// FileName is the name of the configuration file inside the config dir
+const FileName = "config.yaml"
+
+// Config holds user preferences
+type Config struct {
+	Quote   QuoteConfig   `yaml:"quote"`
+	Reflect ReflectConfig `yaml:"reflect"`
+	Anchor  AnchorConfig  `yaml:"anchor"`
+	Log     LogConfig     `yaml:"log"`
+	Notes   NotesConfig   `yaml:"notes"`
+	Sit     SitConfig     `yaml:"sit"`
+}
+
+// QuoteConfig controls how quotes appear
+type QuoteConfig struct {
+	Style string        `yaml:"style"` // typewriter, fade or instant
+	Speed time.Duration `yaml:"speed"` // Delay per character; 0 shows quotes instantly
+}
+
+// ReflectConfig controls reflection pacing
+type ReflectConfig struct {
+	AutoAdvance time.Duration `yaml:"auto_advance"` // Move on from a prompt after this long; 0 waits for a key
+}
+
+// AnchorConfig controls the interactive anchor
+type AnchorConfig struct {
+	MaxInhale time.Duration `yaml:"max_inhale"` // How long a full inhale takes to fill the bar
+}
+
+// LogConfig controls the local practice log
+type LogConfig struct {
+	Enabled bool `yaml:"enabled"` // Record practice sessions in the data dir; off by default
+}
+
+// NotesConfig controls noting practice
+type NotesConfig struct {
+	Keys map[string]string `yaml:"keys"` // Key to label, like p: planning; replaces the defaults
+}
+
+// SitConfig controls the meditation timer
+type SitConfig struct {
+	Bell        string        `yaml:"bell"`         // bel, flash, both or none
+	BellCommand string        `yaml:"bell_command"` // Run instead of the bell, such as a command playing a sound
+	Prepare     time.Duration `yaml:"prepare"`      // Countdown before the start bell; 0 starts at once
+	Progress    bool          `yaml:"progress"`     // Show a dim progress line
+}
+
+// Default returns the configuration used when no file exists
+func Default() Config {
+	return Config{
+		Quote: QuoteConfig{
+			Style: "typewriter",
+			Speed: 50 * time.Millisecond,
+		},
+		Anchor: AnchorConfig{
+			MaxInhale: 4 * time.Second,
+		},
+		Sit: SitConfig{
+			Bell:     "bel",
+			Progress: true,
+		},
+	}
+}
+
+// Path returns the location of the configuration file
+func Path() string {
+	return filepath.Join(storage.ConfigDir(), FileName)
+}
+
+// Load reads the configuration file, filling unset values with defaults.
+// A missing file is not an error.
+func Load() (Config, error) {
+	return LoadFile(Path())
+}
+
+// LoadFile reads configuration from path, filling unset values with defaults
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+
+	// #nosec G304 -- reading the user's own config file
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.Quote.Speed < 0 {
+		return Default(), fmt.Errorf("%s: quote speed must not be negative", path)
+	}
+	if cfg.Reflect.AutoAdvance < 0 {
+		return Default(), fmt.Errorf("%s: reflect auto_advance must not be negative", path)
+	}
+	if cfg.Sit.Prepare < 0 {
+		return Default(), fmt.Errorf("%s: sit prepare must not be negative", path)
+	}
+	if cfg.Anchor.MaxInhale <= 0 {
+		return Default(), fmt.Errorf("%s: anchor max_inhale must be positive", path)
+	}
+	return cfg, nil
+}
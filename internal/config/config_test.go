@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFileMissing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Expected missing file to be fine, got %v", err)
+	}
+	if cfg.Quote != Default().Quote {
+		t.Errorf("Expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadFilePartial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("quote:\n  speed: 20ms\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if cfg.Quote.Speed != 20*time.Millisecond {
+		t.Errorf("Expected speed 20ms, got %v", cfg.Quote.Speed)
+	}
+	if cfg.Quote.Style != Default().Quote.Style {
+		t.Errorf("Expected unset style to keep its default, got %q", cfg.Quote.Style)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	for _, content := range []string{"quote:\n  speed: fast\n", "quote:\n  speed: -5ms\n", "reflect:\n  auto_advance: -1s\n", "anchor:\n  max_inhale: 0s\n", "quote: [\n"} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package githooks installs git hooks that invite a mindful pause before
+// committing or pushing. Existing hooks are preserved and chained.
+package githooks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Marker identifies hook files written by zenta
+const Marker = "# zenta-hook"
+
+// ChainedSuffix is appended to a pre-existing hook that zenta chains to
+const ChainedSuffix = ".zenta-chained"
+
+// Hooks lists the git hooks zenta can install
+var Hooks = []string{"pre-commit", "pre-push", "post-merge"}
+
+// DefaultHook is installed when no hook is requested explicitly
+const DefaultHook = "pre-commit"
+
+// Mode selects what the hook does when it runs
+type Mode string
+
+// Supported hook modes
+const (
+	ModeBreathe Mode = "breathe" // A single breathing cycle
+	ModeQuote   Mode = "quote"   // Just a quote
+)
+
+// ciVariables are set by common CI systems, where hooks must stay silent
+var ciVariables = []string{
+	"CI", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "JENKINS_URL",
+	"TF_BUILD", "CIRCLECI", "TRAVIS", "TEAMCITY_VERSION",
+}
+
+// ErrNotOurs is returned when a hook exists but wasn't installed by zenta
+var ErrNotOurs = errors.New("hook was not installed by zenta")
+
+// IsSupported reports whether zenta can install the named hook
+func IsSupported(hook string) bool {
+	for _, h := range Hooks {
+		if h == hook {
+			return true
+		}
+	}
+	return false
+}
+
+// HooksDir returns the hooks directory of the current repository,
+// honoring core.hooksPath.
+func HooksDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("not inside a git repository")
+	}
+	return filepath.Clean(strings.TrimSpace(string(out))), nil
+}
+
+// Script returns the shell script for a hook that calls back into zenta
+func Script(hook, program string, mode Mode) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "%s: %s\n", Marker, hook)
+	b.WriteString("# Installed by 'zenta git install-hooks'. Remove with 'zenta git uninstall-hooks'.\n")
+	fmt.Fprintf(&b, "chained=\"$(dirname \"$0\")/%s%s\"\n", hook, ChainedSuffix)
+	b.WriteString("if [ -x \"$chained\" ]; then\n")
+	b.WriteString("    \"$chained\" \"$@\" || exit $?\n")
+	b.WriteString("fi\n")
+	fmt.Fprintf(&b, "command -v %s >/dev/null 2>&1 || exit 0\n", shellQuote(program))
+	fmt.Fprintf(&b, "%s git run-hook %s --%s </dev/null || true\n", shellQuote(program), hook, mode)
+	return b.String()
+}
+
+// Install writes the named hook into dir. A pre-existing hook that zenta
+// didn't write is kept and chained so it still runs first.
+func Install(dir, hook, program string, mode Mode) error {
+	if !IsSupported(hook) {
+		return fmt.Errorf("unsupported hook %q", hook)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, hook)
+	ours, exists, err := inspect(path)
+	if err != nil {
+		return err
+	}
+
+	if exists && !ours {
+		chained := path + ChainedSuffix
+		if _, err := os.Stat(chained); err == nil {
+			return fmt.Errorf("%s already exists; refusing to overwrite it", chained)
+		}
+		if err := os.Rename(path, chained); err != nil {
+			return err
+		}
+	}
+
+	// #nosec G306 -- git hooks must be executable
+	return os.WriteFile(path, []byte(Script(hook, program, mode)), 0o755)
+}
+
+// Uninstall removes a zenta hook from dir and restores any chained hook.
+// It returns false when there was nothing to remove.
+func Uninstall(dir, hook string) (bool, error) {
+	path := filepath.Join(dir, hook)
+	ours, exists, err := inspect(path)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+	if !ours {
+		return false, ErrNotOurs
+	}
+
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+
+	chained := path + ChainedSuffix
+	if _, err := os.Stat(chained); err == nil {
+		if err := os.Rename(chained, path); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// SkipReason explains why a hook should stay silent in this environment,
+// or returns an empty string when it may run.
+func SkipReason(getenv func(string) string) string {
+	if getenv("ZENTA_SKIP_HOOKS") != "" {
+		return "ZENTA_SKIP_HOOKS is set"
+	}
+	for _, name := range ciVariables {
+		if getenv(name) != "" {
+			return "running in CI"
+		}
+	}
+	return ""
+}
+
+// inspect reports whether a hook file exists and whether zenta wrote it
+func inspect(path string) (ours, exists bool, err error) {
+	// #nosec G304 -- path is inside the repository's hooks directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return bytes.Contains(data, []byte(Marker)), true, nil
+}
+
+// shellQuote wraps a value in single quotes for POSIX sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,124 @@
+package githooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	script := Script("pre-push", "zenta", ModeQuote)
+
+	if !strings.HasPrefix(script, "#!/bin/sh\n") {
+		t.Error("Expected a POSIX sh shebang")
+	}
+	if !strings.Contains(script, Marker+": pre-push") {
+		t.Error("Expected the zenta marker with the hook name")
+	}
+	if !strings.Contains(script, "pre-push"+ChainedSuffix) {
+		t.Error("Expected the script to chain to a preserved hook")
+	}
+	if !strings.Contains(script, "'zenta' git run-hook pre-push --quote") {
+		t.Errorf("Expected the script to call back into zenta, got:\n%s", script)
+	}
+}
+
+func TestInstallAndUninstall(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Install(dir, "pre-commit", "zenta", ModeBreathe); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "pre-commit"))
+	if err != nil {
+		t.Fatalf("Expected hook to be written: %v", err)
+	}
+	if info.Mode()&0o111 == 0 {
+		t.Error("Expected hook to be executable")
+	}
+
+	// Reinstalling our own hook should simply update it
+	if err := Install(dir, "pre-commit", "zenta", ModeQuote); err != nil {
+		t.Fatalf("Reinstall failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pre-commit"+ChainedSuffix)); err == nil {
+		t.Error("Expected reinstall not to chain to our own hook")
+	}
+
+	removed, err := Uninstall(dir, "pre-commit")
+	if err != nil || !removed {
+		t.Fatalf("Expected hook to be removed, got removed=%v err=%v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pre-commit")); !os.IsNotExist(err) {
+		t.Error("Expected hook file to be gone")
+	}
+}
+
+func TestInstallChainsExistingHook(t *testing.T) {
+	dir := t.TempDir()
+	existing := "#!/bin/sh\nmake lint\n"
+	path := filepath.Join(dir, "pre-commit")
+	if err := os.WriteFile(path, []byte(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(dir, "pre-commit", "zenta", ModeBreathe); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	chained, err := os.ReadFile(path + ChainedSuffix)
+	if err != nil || string(chained) != existing {
+		t.Fatalf("Expected existing hook to be preserved, got %q (%v)", chained, err)
+	}
+
+	if _, err := Uninstall(dir, "pre-commit"); err != nil {
+		t.Fatalf("Uninstall failed: %v", err)
+	}
+
+	restored, err := os.ReadFile(path)
+	if err != nil || string(restored) != existing {
+		t.Errorf("Expected existing hook to be restored, got %q (%v)", restored, err)
+	}
+}
+
+func TestUninstallLeavesForeignHooks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pre-push")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Uninstall(dir, "pre-push"); err != ErrNotOurs {
+		t.Errorf("Expected ErrNotOurs, got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Expected foreign hook to be left in place")
+	}
+}
+
+func TestInstallUnsupportedHook(t *testing.T) {
+	if err := Install(t.TempDir(), "commit-msg", "zenta", ModeBreathe); err == nil {
+		t.Error("Expected error for unsupported hook")
+	}
+}
+
+func TestSkipReason(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	if reason := SkipReason(getenv); reason != "" {
+		t.Errorf("Expected hooks to run in a plain environment, got %q", reason)
+	}
+
+	env["GITHUB_ACTIONS"] = "true"
+	if SkipReason(getenv) == "" {
+		t.Error("Expected hooks to skip in CI")
+	}
+
+	env = map[string]string{"ZENTA_SKIP_HOOKS": "1"}
+	if SkipReason(getenv) == "" {
+		t.Error("Expected hooks to skip when ZENTA_SKIP_HOOKS is set")
+	}
+}
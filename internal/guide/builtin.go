@@ -0,0 +1,89 @@
+package guide
+
+import (
+	"time"
+
+	"github.com/e6a5/zenta/internal/reflection"
+)
+
+// BuiltinScripts returns the scripts that ship with zenta
+func BuiltinScripts() []Script {
+	return append([]Script(nil), builtinScripts...)
+}
+
+// steps joins groups of steps into one sequence
+func steps(groups ...[]Step) []Step {
+	var all []Step
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return all
+}
+
+// builtinScripts are the scripts compiled into the binary, in name order
+var builtinScripts = []Script{
+	{
+		Name:  "body-scan",
+		Title: "🌿  Body Scan",
+		Steps: steps(
+			say(reflection.InstructionPause,
+				"Sit or lie down comfortably.",
+				"Let your eyes close, or rest them softly on the floor.",
+			),
+			[]Step{{Kind: StepBreathe, Cycles: 2, Pattern: "calm"}},
+			say(10*time.Second,
+				"Bring your attention to your feet. Notice whatever is there.",
+				"Move up through your legs: calves, knees, thighs.",
+				"Notice your hips and lower back, and the weight of your body held.",
+				"Your belly and chest, rising and falling with the breath.",
+				"Your hands, your arms, your shoulders. Let them soften.",
+				"Your neck, your jaw, the muscles around your eyes.",
+				"Now the whole body at once, breathing.",
+			),
+			[]Step{{Kind: StepSit, Duration: time.Minute}},
+			say(reflection.ClosingPause,
+				"Wiggle your fingers and toes.",
+				"Carry this awareness back into your day. 🙏",
+			),
+		),
+	},
+	{
+		Name:  "loving-kindness",
+		Title: "💗  Loving-Kindness",
+		Steps: steps(
+			say(reflection.InstructionPause,
+				"Sit comfortably and let your breath settle.",
+				"Bring to mind someone who cares for you. Feel their warmth.",
+			),
+			say(8*time.Second,
+				"May you be happy. May you be well. May you be at ease.",
+			),
+			say(reflection.InstructionPause,
+				"Now turn those wishes toward yourself.",
+			),
+			say(8*time.Second,
+				"May I be happy. May I be well. May I be at ease.",
+			),
+			say(reflection.InstructionPause,
+				"Bring to mind someone you work with, someone neutral.",
+			),
+			say(8*time.Second,
+				"May you be happy. May you be well. May you be at ease.",
+			),
+			say(reflection.InstructionPause,
+				"And someone who is difficult for you, as far as feels right today.",
+			),
+			say(8*time.Second,
+				"May you be happy. May you be well. May you be at ease.",
+			),
+			say(reflection.InstructionPause,
+				"Finally, everyone, everywhere.",
+			),
+			say(8*time.Second,
+				"May all beings be happy. May all beings be well. May all beings be at ease.",
+			),
+			[]Step{{Kind: StepSit, Duration: 30 * time.Second}, {Kind: StepQuote}},
+		),
+	},
+	FromPromptSet("reflect", reflection.GetDefaultPrompts()),
+}
@@ -0,0 +1,158 @@
+// Package guide describes guided sessions as scripts: a sequence of steps
+// such as spoken lines, pauses, breathing, quotes and silent sitting.
+package guide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/e6a5/zenta/internal/reflection"
+)
+
+// Kind is what a step does
+type Kind string
+
+// The kinds of step a script can take
+const (
+	StepSay     Kind = "say"     // Show a line of text, then pause
+	StepPause   Kind = "pause"   // Pause in silence
+	StepBreathe Kind = "breathe" // Breathe some cycles, optionally with a pattern
+	StepWait    Kind = "wait"    // Wait for a key press
+	StepQuote   Kind = "quote"   // Show a quote
+	StepSit     Kind = "sit"     // Sit silently for a while
+	StepPrompts Kind = "prompts" // Reflection prompts, paced by the user
+)
+
+// Limits that keep a script from running away
+const (
+	MaxCycles = 20
+	MaxSit    = 2 * time.Hour
+)
+
+// Step is one step of a guided session
+type Step struct {
+	Kind       Kind
+	Text       string            // The line to say, the wait message or the prompts title
+	Pause      time.Duration     // After a line, or the length of a pause
+	Cycles     int               // Breathing cycles
+	Pattern    string            // Breathing pattern; empty for the default
+	Collection string            // Quote collection; empty for any
+	Duration   time.Duration     // Length of a sit
+	Prompts    []reflection.Line // Reflection prompts
+}
+
+// Script is a guided session
+type Script struct {
+	Name   string
+	Title  string
+	Steps  []Step
+	Source string // File the script was loaded from; empty for built-in scripts
+}
+
+// Validate checks a script for missing text and unreasonable lengths
+func (s Script) Validate() error {
+	if s.Title == "" {
+		return fmt.Errorf("missing title")
+	}
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("no steps")
+	}
+
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			return &StepError{Index: i, Kind: step.Kind, Err: err}
+		}
+	}
+	return nil
+}
+
+// StepError reports a problem with one step of a script
+type StepError struct {
+	Index int // Position of the step, from zero
+	Kind  Kind
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s): %v", e.Index+1, e.Kind, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// validate checks a single step
+func (st Step) validate() error {
+	if st.Pause < 0 || st.Pause > reflection.MaxPause {
+		return fmt.Errorf("pause must be between 0 and %v", reflection.MaxPause)
+	}
+
+	switch st.Kind {
+	case StepSay, StepWait, StepQuote:
+	case StepPause:
+		if st.Pause <= 0 {
+			return fmt.Errorf("pause needs a length, like 10s")
+		}
+	case StepBreathe:
+		if st.Cycles < 1 || st.Cycles > MaxCycles {
+			return fmt.Errorf("cycles must be between 1 and %d", MaxCycles)
+		}
+	case StepSit:
+		if st.Duration <= 0 || st.Duration > MaxSit {
+			return fmt.Errorf("sit must be between 1s and %v", MaxSit)
+		}
+	case StepPrompts:
+		if len(st.Prompts) == 0 {
+			return fmt.Errorf("no prompts")
+		}
+		for i, line := range st.Prompts {
+			if line.Text == "" {
+				return fmt.Errorf("prompt %d has no text", i+1)
+			}
+			if line.Pause < 0 || line.Pause > reflection.MaxPause {
+				return fmt.Errorf("prompt %d: pause must be between 0 and %v", i+1, reflection.MaxPause)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown step")
+	}
+	return nil
+}
+
+// Patterns returns the breathing patterns a script uses, so they can be
+// checked before it runs
+func (s Script) Patterns() []string {
+	var names []string
+	for _, step := range s.Steps {
+		if step.Kind == StepBreathe && step.Pattern != "" {
+			names = append(names, step.Pattern)
+		}
+	}
+	return names
+}
+
+// FromPromptSet expresses a reflection prompt set as a script: its
+// instructions, the prompts and the closing lines, with a blank line
+// between each section
+func FromPromptSet(name string, ps reflection.PromptSet) Script {
+	script := Script{Name: name, Title: ps.Title, Source: ps.Source}
+	blank := Step{Kind: StepSay}
+
+	for _, line := range ps.Instructions {
+		script.Steps = append(script.Steps, Step{Kind: StepSay, Text: line.Text, Pause: line.Pause})
+	}
+	script.Steps = append(script.Steps, blank, Step{Kind: StepPrompts, Text: ps.PromptTitle, Prompts: ps.Prompts}, blank)
+	for _, line := range ps.Closing {
+		script.Steps = append(script.Steps, Step{Kind: StepSay, Text: line.Text, Pause: line.Pause})
+	}
+	return script
+}
+
+// say builds steps that show lines, each with the same pause
+func say(pause time.Duration, texts ...string) []Step {
+	steps := make([]Step, len(texts))
+	for i, text := range texts {
+		steps[i] = Step{Kind: StepSay, Text: text, Pause: pause}
+	}
+	return steps
+}
@@ -0,0 +1,40 @@
+package guide
+
+import (
+	"testing"
+
+	"github.com/e6a5/zenta/internal/reflection"
+)
+
+func TestBuiltinScriptsAreValid(t *testing.T) {
+	for _, script := range BuiltinScripts() {
+		if err := script.Validate(); err != nil {
+			t.Errorf("%s: %v", script.Name, err)
+		}
+	}
+}
+
+func TestFromPromptSet(t *testing.T) {
+	set := reflection.GetDefaultPrompts()
+	script := FromPromptSet("reflect", set)
+
+	if script.Title != set.Title {
+		t.Errorf("Expected the set's title, got %q", script.Title)
+	}
+
+	var prompts []Step
+	for _, step := range script.Steps {
+		if step.Kind == StepPrompts {
+			prompts = append(prompts, step)
+		}
+	}
+	if len(prompts) != 1 || prompts[0].Text != set.PromptTitle || len(prompts[0].Prompts) != len(set.Prompts) {
+		t.Errorf("Expected one prompts step with the set's prompts, got %+v", prompts)
+	}
+
+	// Instructions, a blank line, the prompts, a blank line, the closing
+	want := len(set.Instructions) + 3 + len(set.Closing)
+	if len(script.Steps) != want {
+		t.Errorf("Expected %d steps, got %d", want, len(script.Steps))
+	}
+}
@@ -0,0 +1,223 @@
+package guide
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/e6a5/zenta/internal/named"
+	"github.com/e6a5/zenta/internal/reflection"
+)
+
+// yamlScript is the file format of a script
+type yamlScript struct {
+	Title string     `yaml:"title"`
+	Steps []yamlStep `yaml:"steps"`
+}
+
+// yamlStep is a step in a script file: a mapping with one main key, such
+// as say or breathe, and the options that go with it. The steps without
+// options may be written alone, as "wait" or "quote".
+type yamlStep struct {
+	Step
+	line int
+}
+
+// stepKeys lists the keys each kind of step may use besides its own
+var stepKeys = map[Kind][]string{
+	StepSay:     {"pause"},
+	StepPause:   nil,
+	StepBreathe: {"pattern"},
+	StepWait:    nil,
+	StepQuote:   nil,
+	StepSit:     nil,
+	StepPrompts: {"title"},
+}
+
+// UnmarshalYAML reads a step, checking it has exactly one main key
+func (s *yamlStep) UnmarshalYAML(value *yaml.Node) error {
+	s.line = value.Line
+
+	if value.Kind == yaml.ScalarNode {
+		switch Kind(value.Value) {
+		case StepWait, StepQuote:
+			s.Kind = Kind(value.Value)
+			return nil
+		}
+		return fmt.Errorf("line %d: unknown step %q", value.Line, value.Value)
+	}
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: a step must be a mapping like 'say: text'", value.Line)
+	}
+
+	var raw struct {
+		Say     *string        `yaml:"say"`
+		Pause   *time.Duration `yaml:"pause"`
+		Breathe *int           `yaml:"breathe"`
+		Pattern string         `yaml:"pattern"`
+		Wait    *string        `yaml:"wait"`
+		Quote   *string        `yaml:"quote"`
+		Sit     *time.Duration `yaml:"sit"`
+		Prompts []yamlPrompt   `yaml:"prompts"`
+		Title   string         `yaml:"title"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	// Find the step's kind from its main key
+	var keys []string
+	for i := 0; i < len(value.Content); i += 2 {
+		keys = append(keys, value.Content[i].Value)
+	}
+	main := ""
+	for _, key := range keys {
+		if _, ok := stepKeys[Kind(key)]; ok && key != string(StepPause) {
+			if main != "" {
+				return fmt.Errorf("line %d: a step can't both %s and %s", value.Line, main, key)
+			}
+			main = key
+		}
+	}
+	if main == "" {
+		main = string(StepPause)
+	}
+	s.Kind = Kind(main)
+
+	// Only the main key and its options are allowed
+	for _, key := range keys {
+		if key != main && !slices.Contains(stepKeys[s.Kind], key) {
+			return fmt.Errorf("line %d: %s step doesn't take %q", value.Line, main, key)
+		}
+	}
+
+	switch s.Kind {
+	case StepSay:
+		s.Text = strings.TrimSpace(deref(raw.Say))
+		// A blank line needs no pause
+		if s.Text != "" {
+			s.Pause = reflection.InstructionPause
+		}
+	case StepBreathe:
+		s.Cycles, s.Pattern = deref(raw.Breathe), strings.TrimSpace(raw.Pattern)
+	case StepWait:
+		s.Text = strings.TrimSpace(deref(raw.Wait))
+	case StepQuote:
+		s.Collection = strings.TrimSpace(deref(raw.Quote))
+	case StepSit:
+		s.Duration = deref(raw.Sit)
+	case StepPrompts:
+		s.Text = strings.TrimSpace(raw.Title)
+		for _, p := range raw.Prompts {
+			s.Prompts = append(s.Prompts, p.Line)
+		}
+	}
+	if raw.Pause != nil {
+		s.Pause = *raw.Pause
+	}
+	return nil
+}
+
+// yamlPrompt is a prompt given either as plain text or as text with a pause
+type yamlPrompt struct {
+	reflection.Line
+}
+
+// UnmarshalYAML accepts a plain string as a prompt with the default pause
+func (p *yamlPrompt) UnmarshalYAML(value *yaml.Node) error {
+	p.Pause = reflection.PromptPause
+	if value.Kind == yaml.ScalarNode {
+		p.Text = strings.TrimSpace(value.Value)
+		return nil
+	}
+
+	var raw struct {
+		Text  string         `yaml:"text"`
+		Pause *time.Duration `yaml:"pause"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	p.Text = strings.TrimSpace(raw.Text)
+	if raw.Pause != nil {
+		p.Pause = *raw.Pause
+	}
+	return nil
+}
+
+// deref returns the value v points to, or the zero value for an empty key
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+// LoadFile reads and validates a script. It is named after the file.
+func LoadFile(path string) (Script, error) {
+	// #nosec G304 -- reading the user's own script
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Script{}, err
+	}
+
+	var raw yamlScript
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		return Script{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	script := Script{
+		Name:   strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Title:  strings.TrimSpace(raw.Title),
+		Source: path,
+	}
+	for _, step := range raw.Steps {
+		script.Steps = append(script.Steps, step.Step)
+	}
+
+	if err := script.Validate(); err != nil {
+		// Point at the line of a step that failed
+		var stepErr *StepError
+		if errors.As(err, &stepErr) && stepErr.Index < len(raw.Steps) {
+			return Script{}, fmt.Errorf("%s:%d: %w", path, raw.Steps[stepErr.Index].line, err)
+		}
+		return Script{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return script, nil
+}
+
+// scriptFiles finds scripts by name
+var scriptFiles = named.Files[Script]{
+	Kind:     "guide",
+	Builtin:  builtinScripts,
+	Name:     func(x Script) string { return x.Name },
+	LoadFile: LoadFile,
+}
+
+// LoadDir reads every script (*.yaml, *.yml) in dir. A missing directory
+// is not an error; invalid files are skipped and reported.
+func LoadDir(dir string) ([]Script, []error) {
+	return scriptFiles.LoadDir(dir)
+}
+
+// Available returns the built-in scripts together with those in dir,
+// sorted by name. A file named after a built-in script replaces it.
+func Available(dir string) ([]Script, []error) {
+	return scriptFiles.Available(dir)
+}
+
+// Find returns the script called name, looking in dir and then the
+// built-in scripts. A name that is a path to a file loads that file.
+func Find(name, dir string) (Script, error) {
+	return scriptFiles.Find(name, dir)
+}
@@ -0,0 +1,99 @@
+package guide
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/e6a5/zenta/internal/reflection"
+	"github.com/e6a5/zenta/internal/testutil"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := testutil.WriteFile(t, t.TempDir(), "lunch.yaml", `title: After Lunch
+steps:
+  - say: Sit back from the keyboard.
+  - say: ""
+  - say: Notice your hands.
+    pause: 10s
+  - pause: 5s
+  - breathe: 3
+    pattern: calm
+  - wait
+  - wait: Press a key when you're ready.
+  - sit: 2m
+  - prompts:
+      - What do you need from the team?
+      - text: What can you offer?
+        pause: 20s
+    title: "Before you speak:"
+  - quote: stoic
+`)
+
+	script, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if script.Name != "lunch" || script.Title != "After Lunch" || len(script.Steps) != 10 {
+		t.Fatalf("Unexpected script %+v", script)
+	}
+
+	want := []Step{
+		{Kind: StepSay, Text: "Sit back from the keyboard.", Pause: reflection.InstructionPause},
+		{Kind: StepSay},
+		{Kind: StepSay, Text: "Notice your hands.", Pause: 10 * time.Second},
+		{Kind: StepPause, Pause: 5 * time.Second},
+		{Kind: StepBreathe, Cycles: 3, Pattern: "calm"},
+		{Kind: StepWait},
+		{Kind: StepWait, Text: "Press a key when you're ready."},
+		{Kind: StepSit, Duration: 2 * time.Minute},
+	}
+	for i, step := range want {
+		if script.Steps[i].Kind != step.Kind || script.Steps[i].Text != step.Text || script.Steps[i].Pause != step.Pause ||
+			script.Steps[i].Cycles != step.Cycles || script.Steps[i].Pattern != step.Pattern || script.Steps[i].Duration != step.Duration {
+			t.Errorf("Step %d: expected %+v, got %+v", i+1, step, script.Steps[i])
+		}
+	}
+
+	prompts := script.Steps[8]
+	if prompts.Kind != StepPrompts || prompts.Text != "Before you speak:" || len(prompts.Prompts) != 2 ||
+		prompts.Prompts[0].Pause != reflection.PromptPause || prompts.Prompts[1].Pause != 20*time.Second {
+		t.Errorf("Unexpected prompts step %+v", prompts)
+	}
+	if quote := script.Steps[9]; quote.Kind != StepQuote || quote.Collection != "stoic" {
+		t.Errorf("Unexpected quote step %+v", quote)
+	}
+	if patterns := script.Patterns(); len(patterns) != 1 || patterns[0] != "calm" {
+		t.Errorf("Expected the calm pattern to be listed, got %v", patterns)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		name, content, want string
+	}{
+		{"no title", "steps:\n  - say: Hello\n", "missing title"},
+		{"no steps", "title: Empty\n", "no steps"},
+		{"two kinds", "title: T\nsteps:\n  - say: Hello\n    sit: 1m\n", "can't both say and sit"},
+		{"wrong option", "title: T\nsteps:\n  - sit: 1m\n    pattern: calm\n", `sit step doesn't take "pattern"`},
+		{"unknown step", "title: T\nsteps:\n  - dance\n", `unknown step "dance"`},
+		{"no cycles", "title: T\nsteps:\n  - breathe: 0\n", "cycles must be between"},
+		{"long sit", "title: T\nsteps:\n  - sit: 3h\n", "sit must be between"},
+		{"empty prompts", "title: T\nsteps:\n  - prompts: []\n", "(prompts): no prompts"},
+		{"unknown field", "title: T\nsteps:\n  - say: Hi\nmusic: yes\n", "field music not found"},
+	}
+
+	for _, tc := range testCases {
+		path := testutil.WriteFile(t, dir, "bad.yaml", tc.content)
+		if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	// Step errors point at the step's line
+	path := testutil.WriteFile(t, dir, "bad.yaml", "title: T\nsteps:\n  - say: Hi\n  - breathe: 50\n")
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), "bad.yaml:4: step 2 (breathe)") {
+		t.Errorf("Expected the error to name line 4, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Package named finds things that are known by name and written as YAML
+// files: guided scripts, prompt sets and programs. Each kind has some
+// built into zenta, and the user's own in a directory, where a file named
+// after a built-in one replaces it.
+package named
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Files describes one kind of named file and how to read it
+type Files[T any] struct {
+	Kind     string                       // What one is called in errors, like "guide"
+	Builtin  []T                          // Those compiled into the binary
+	Name     func(T) string               // The name of one
+	LoadFile func(path string) (T, error) // Reads and validates a file
+}
+
+// LoadDir reads every file (*.yaml, *.yml) in dir, sorted by name. A
+// missing directory is not an error; invalid files are skipped and
+// reported.
+func (f Files[T]) LoadDir(dir string) ([]T, []error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var items []T
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		item, err := f.LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+	f.sort(items)
+	return items, errs
+}
+
+// Available returns the built-in ones together with those in dir, sorted
+// by name
+func (f Files[T]) Available(dir string) ([]T, []error) {
+	byName := make(map[string]T)
+	for _, item := range f.Builtin {
+		byName[f.Name(item)] = item
+	}
+	userItems, errs := f.LoadDir(dir)
+	for _, item := range userItems {
+		byName[f.Name(item)] = item
+	}
+
+	items := make([]T, 0, len(byName))
+	for _, item := range byName {
+		items = append(items, item)
+	}
+	f.sort(items)
+	return items, errs
+}
+
+// Find returns the one called name, looking in dir and then the built-in
+// ones. A name that is a path to a file loads that file.
+func (f Files[T]) Find(name, dir string) (T, error) {
+	if strings.ContainsRune(name, os.PathSeparator) || filepath.Ext(name) != "" {
+		return f.LoadFile(name)
+	}
+
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return f.LoadFile(path)
+		}
+	}
+
+	for _, item := range f.Builtin {
+		if f.Name(item) == name {
+			return item, nil
+		}
+	}
+
+	items, _ := f.Available(dir)
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = f.Name(item)
+	}
+	var zero T
+	return zero, fmt.Errorf("unknown %s %q (available: %s)", f.Kind, name, strings.Join(names, ", "))
+}
+
+// sort orders items by name
+func (f Files[T]) sort(items []T) {
+	sort.Slice(items, func(i, j int) bool { return f.Name(items[i]) < f.Name(items[j]) })
+}
@@ -0,0 +1,88 @@
+package named
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/e6a5/zenta/internal/testutil"
+)
+
+// item stands in for a script, prompt set or program
+type item struct {
+	Name, Title, Source string
+}
+
+// testFiles reads "title: ..." files, rejecting any without a title
+var testFiles = Files[item]{
+	Kind:    "item",
+	Builtin: []item{{Name: "calm", Title: "Built-in calm"}, {Name: "focus", Title: "Built-in focus"}},
+	Name:    func(i item) string { return i.Name },
+	LoadFile: func(path string) (item, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return item{}, err
+		}
+		title, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "title: ")
+		if !ok {
+			return item{}, fmt.Errorf("%s: missing title", path)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return item{Name: name, Title: title, Source: path}, nil
+	},
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "b.yml", "title: B")
+	testutil.WriteFile(t, dir, "a.yaml", "title: A")
+	testutil.WriteFile(t, dir, "broken.yaml", "nothing here")
+	testutil.WriteFile(t, dir, "notes.txt", "title: Ignored")
+
+	items, errs := testFiles.LoadDir(dir)
+	if len(items) != 2 || items[0].Name != "a" || items[1].Name != "b" {
+		t.Errorf("Expected a and b in order, got %+v", items)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Expected the broken file to be reported, got %v", errs)
+	}
+
+	if items, errs := testFiles.LoadDir(filepath.Join(dir, "missing")); items != nil || errs != nil {
+		t.Errorf("Expected nothing from a missing directory, got %v, %v", items, errs)
+	}
+}
+
+func TestAvailable(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "calm.yaml", "title: My calm")
+	testutil.WriteFile(t, dir, "evening.yaml", "title: Evening")
+
+	items, _ := testFiles.Available(dir)
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %+v", items)
+	}
+	if items[0].Name != "calm" || items[0].Title != "My calm" {
+		t.Errorf("Expected the user's file to replace the built-in, got %+v", items[0])
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "calm.yaml", "title: My calm")
+	other := testutil.WriteFile(t, t.TempDir(), "elsewhere.yml", "title: Elsewhere")
+
+	if i, err := testFiles.Find("calm", dir); err != nil || i.Title != "My calm" {
+		t.Errorf("Expected the user's file first, got %+v (%v)", i, err)
+	}
+	if i, err := testFiles.Find("focus", dir); err != nil || i.Source != "" {
+		t.Errorf("Expected the built-in one, got %+v (%v)", i, err)
+	}
+	if i, err := testFiles.Find(other, dir); err != nil || i.Title != "Elsewhere" {
+		t.Errorf("Expected a file loaded by path, got %+v (%v)", i, err)
+	}
+	if _, err := testFiles.Find("nope", dir); err == nil || !strings.Contains(err.Error(), `unknown item "nope" (available: calm, focus)`) {
+		t.Errorf("Expected an error listing what is available, got %v", err)
+	}
+}
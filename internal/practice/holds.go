@@ -0,0 +1,36 @@
+package practice
+
+import (
+	"math"
+	"time"
+)
+
+// HoldSeconds converts breath holds for an entry, to a tenth of a second
+func HoldSeconds(holds []time.Duration) []float64 {
+	seconds := make([]float64, len(holds))
+	for i, held := range holds {
+		seconds[i] = math.Round(held.Seconds()*10) / 10
+	}
+	return seconds
+}
+
+// HoldTrend returns the longest hold of each of the last n sessions of a
+// practice, such as "hold" or "bolt", oldest first
+func HoldTrend(entries []Entry, practice string, n int) []time.Duration {
+	var trend []time.Duration
+	for _, e := range entries {
+		if e.Practice != practice || len(e.Holds) == 0 {
+			continue
+		}
+		var longest float64
+		for _, held := range e.Holds {
+			longest = max(longest, held)
+		}
+		trend = append(trend, time.Duration(longest*float64(time.Second)))
+	}
+
+	if len(trend) > n {
+		trend = trend[len(trend)-n:]
+	}
+	return trend
+}
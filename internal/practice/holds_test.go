@@ -0,0 +1,32 @@
+package practice
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHoldTrend(t *testing.T) {
+	entries := []Entry{
+		{Practice: "hold", Holds: []float64{30, 42.5}},
+		{Practice: "bolt", Holds: []float64{21}},
+		{Practice: "count", Breaths: 20},
+		{Practice: "hold", Holds: []float64{35, 38}},
+		{Practice: "hold", Holds: []float64{44}},
+	}
+
+	want := []time.Duration{38 * time.Second, 44 * time.Second}
+	if got := HoldTrend(entries, "hold", 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the last two longest holds %v, got %v", want, got)
+	}
+	if got := HoldTrend(entries, "bolt", 5); !reflect.DeepEqual(got, []time.Duration{21 * time.Second}) {
+		t.Errorf("Expected one BOLT score, got %v", got)
+	}
+}
+
+func TestHoldSeconds(t *testing.T) {
+	got := HoldSeconds([]time.Duration{34260 * time.Millisecond, 3 * time.Minute})
+	if !reflect.DeepEqual(got, []float64{34.3, 180}) {
+		t.Errorf("Expected holds to a tenth of a second, got %v", got)
+	}
+}
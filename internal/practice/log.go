@@ -0,0 +1,73 @@
+// Package practice keeps the optional local log of practice sessions.
+// Nothing is recorded unless the user turns logging on, and the log lives
+// in zenta's data directory, so the vault encrypts it with everything else.
+package practice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// LogFile is the name of the practice log inside the data dir
+const LogFile = "practice.jsonl"
+
+// Entry is one recorded practice session
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Practice string    `json:"practice"` // The command, such as "count"
+	Seconds  int       `json:"seconds"`
+	Rounds   int       `json:"rounds,omitempty"`   // Full rounds of ten counted breaths
+	Breaths  int       `json:"breaths,omitempty"`  // Breaths counted
+	Wandered int       `json:"wandered,omitempty"` // Times the user noticed their mind had wandered
+	Notes    Tally     `json:"notes,omitempty"`    // Thoughts noted, by label
+	Holds    []float64 `json:"holds,omitempty"`    // Breath holds, in seconds, one per round
+}
+
+// LogPath returns the location of the practice log
+func LogPath() string {
+	return filepath.Join(storage.DataDir(), LogFile)
+}
+
+// Append adds an entry to the log at path, one JSON object per line
+func Append(path string, entry Entry) error {
+	existing, _, err := storage.ReadData(path)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return storage.WriteData(path, append(append(existing, line...), '\n'))
+}
+
+// Read returns every entry in the log at path, oldest first. A missing log
+// has no entries.
+func Read(path string) ([]Entry, error) {
+	data, _, err := storage.ReadData(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return entries, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,47 @@
+package practice
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	path := LogPath()
+
+	if entries, err := Read(path); err != nil || len(entries) != 0 {
+		t.Fatalf("Expected an empty log, got %v, %v", entries, err)
+	}
+
+	when := time.Date(2026, 10, 18, 7, 30, 0, 0, time.UTC)
+	first := Entry{Time: when, Practice: "count", Seconds: 300, Rounds: 2, Breaths: 27, Wandered: 3}
+	second := Entry{Time: when.Add(time.Hour), Practice: "note-practice", Seconds: 600, Notes: Tally{"planning": 4, "judging": 1}}
+	for _, entry := range []Entry{first, second} {
+		if err := Append(path, entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !reflect.DeepEqual(entries, []Entry{first, second}) {
+		t.Errorf("Expected both entries back, got %+v", entries)
+	}
+}
+
+func TestReadReportsBadLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LogFile)
+	if err := os.WriteFile(path, []byte("{\"practice\":\"count\"}\nnot json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Read(path)
+	if err == nil || len(entries) != 1 {
+		t.Errorf("Expected the good entry and an error, got %+v, %v", entries, err)
+	}
+}
@@ -0,0 +1,126 @@
+package practice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultLabels are the thought categories noting practice offers when the
+// config file names none
+var DefaultLabels = Labels{
+	'p': "planning",
+	'r': "remembering",
+	'j': "judging",
+	'w': "worrying",
+}
+
+// Labels maps single keys to the thought categories they note
+type Labels map[byte]string
+
+// ParseLabels reads labels from the config file's key: label map. Keys
+// must be single printable characters other than space and q, which
+// noting practice uses itself.
+func ParseLabels(keys map[string]string) (Labels, error) {
+	labels := Labels{}
+	for key, label := range keys {
+		label = strings.TrimSpace(label)
+		if len(key) != 1 || !unicode.IsPrint(rune(key[0])) || key == " " || key == "q" || key == "Q" {
+			return nil, fmt.Errorf("invalid noting key %q (use a single letter or digit other than q)", key)
+		}
+		if label == "" {
+			return nil, fmt.Errorf("noting key %q has no label", key)
+		}
+		labels[key[0]] = label
+	}
+	return labels, nil
+}
+
+// Keys returns the label keys in order
+func (l Labels) Keys() []byte {
+	keys := make([]byte, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Tally counts the thoughts noted, by label
+type Tally map[string]int
+
+// LabelCount is one label's share of a tally
+type LabelCount struct {
+	Label string
+	Count int
+}
+
+// Total returns how many thoughts were noted
+func (t Tally) Total() int {
+	total := 0
+	for _, n := range t {
+		total += n
+	}
+	return total
+}
+
+// Sorted lists the labels noted, most often first
+func (t Tally) Sorted() []LabelCount {
+	counts := make([]LabelCount, 0, len(t))
+	for label, n := range t {
+		if n > 0 {
+			counts = append(counts, LabelCount{label, n})
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Label < counts[j].Label
+	})
+	return counts
+}
+
+// Mostly returns the labels noted most often, several when they tie
+func (t Tally) Mostly() []string {
+	var labels []string
+	for _, c := range t.Sorted() {
+		if len(labels) > 0 && c.Count < t[labels[0]] {
+			break
+		}
+		labels = append(labels, c.Label)
+	}
+	return labels
+}
+
+// WeekNotes adds up the thoughts noted in the seven days before now
+func WeekNotes(entries []Entry, now time.Time) Tally {
+	since := now.AddDate(0, 0, -7)
+	week := Tally{}
+	for _, entry := range entries {
+		if entry.Time.Before(since) || entry.Time.After(now) {
+			continue
+		}
+		for label, n := range entry.Notes {
+			week[label] += n
+		}
+	}
+	return week
+}
+
+// WeekSummary describes a week of noting, like "This week you noticed
+// mostly planning.", or "" when nothing was noted
+func WeekSummary(week Tally) string {
+	mostly := week.Mostly()
+	switch len(mostly) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("This week you noticed mostly %s.", mostly[0])
+	default:
+		last := len(mostly) - 1
+		return fmt.Sprintf("This week you noticed mostly %s and %s.", strings.Join(mostly[:last], ", "), mostly[last])
+	}
+}
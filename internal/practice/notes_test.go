@@ -0,0 +1,64 @@
+package practice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels(map[string]string{"b": " body ", "7": "sounds"})
+	if err != nil {
+		t.Fatalf("ParseLabels failed: %v", err)
+	}
+	if labels['b'] != "body" || labels['7'] != "sounds" || string(labels.Keys()) != "7b" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+
+	for _, keys := range []map[string]string{{"q": "quiet"}, {" ": "space"}, {"pl": "planning"}, {"p": ""}} {
+		if _, err := ParseLabels(keys); err == nil {
+			t.Errorf("Expected error for %v", keys)
+		}
+	}
+}
+
+func TestTallySorted(t *testing.T) {
+	tally := Tally{"judging": 2, "planning": 5, "worrying": 2, "remembering": 0}
+
+	sorted := tally.Sorted()
+	want := []LabelCount{{"planning", 5}, {"judging", 2}, {"worrying", 2}}
+	if len(sorted) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, sorted)
+	}
+	for i := range want {
+		if sorted[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, sorted)
+		}
+	}
+	if tally.Total() != 9 {
+		t.Errorf("Expected 9 noted, got %d", tally.Total())
+	}
+}
+
+func TestWeekSummary(t *testing.T) {
+	now := time.Date(2026, 10, 18, 21, 0, 0, 0, time.Local)
+	entries := []Entry{
+		{Time: now.AddDate(0, 0, -10), Notes: Tally{"worrying": 20}}, // Last week
+		{Time: now.AddDate(0, 0, -3), Notes: Tally{"planning": 3, "judging": 1}},
+		{Time: now.Add(-time.Hour), Notes: Tally{"planning": 2, "judging": 4}},
+		{Time: now.Add(-time.Hour), Practice: "count", Breaths: 30},
+	}
+
+	week := WeekNotes(entries, now)
+	if got := WeekSummary(week); got != "This week you noticed mostly judging and planning." {
+		t.Errorf("Unexpected summary %q", got)
+	}
+
+	week["planning"]++
+	if got := WeekSummary(week); got != "This week you noticed mostly planning." {
+		t.Errorf("Unexpected summary %q", got)
+	}
+
+	if got := WeekSummary(Tally{}); got != "" {
+		t.Errorf("Expected no summary without notes, got %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package program
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/e6a5/zenta/internal/named"
+)
+
+// yamlProgram is the file format of a program
+type yamlProgram struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Days        []Day  `yaml:"days"`
+}
+
+// LoadFile reads and validates a program. It is named after the file.
+func LoadFile(path string) (Program, error) {
+	// #nosec G304 -- reading the user's own program
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Program{}, err
+	}
+
+	var raw yamlProgram
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		return Program{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	p := Program{
+		Name:        strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Title:       strings.TrimSpace(raw.Title),
+		Description: strings.TrimSpace(raw.Description),
+		Days:        raw.Days,
+		Source:      path,
+	}
+	for i := range p.Days {
+		p.Days[i].Title = strings.TrimSpace(p.Days[i].Title)
+		p.Days[i].Intro = strings.TrimSpace(p.Days[i].Intro)
+	}
+	if err := p.Validate(); err != nil {
+		return Program{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// programFiles finds programs by name
+var programFiles = named.Files[Program]{
+	Kind:     "program",
+	Builtin:  builtinPrograms,
+	Name:     func(x Program) string { return x.Name },
+	LoadFile: LoadFile,
+}
+
+// LoadDir reads every program (*.yaml, *.yml) in dir. A missing directory
+// is not an error; invalid files are skipped and reported.
+func LoadDir(dir string) ([]Program, []error) {
+	return programFiles.LoadDir(dir)
+}
+
+// Available returns the built-in programs together with those in dir,
+// sorted by name. A file named after a built-in program replaces it.
+func Available(dir string) ([]Program, []error) {
+	return programFiles.Available(dir)
+}
+
+// Find returns the program called name, looking in dir and then the
+// built-in programs. A name that is a path to a file loads that file.
+func Find(name, dir string) (Program, error) {
+	return programFiles.Find(name, dir)
+}
@@ -0,0 +1,128 @@
+// Package program describes multi-day practice programs, in which each
+// day unlocks one session, and keeps track of progress through them.
+package program
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sessions a program day can run
+const (
+	SessionNow     = "now"
+	SessionAnchor  = "anchor"
+	SessionCount   = "count"
+	SessionNotes   = "note-practice"
+	SessionSit     = "sit"
+	SessionReflect = "reflect"
+	SessionGuide   = "guide"
+)
+
+// MaxDays is the longest a program may run
+const MaxDays = 366
+
+// Day is one day of a program and the session it unlocks
+type Day struct {
+	Title   string        `yaml:"title"`
+	Intro   string        `yaml:"intro"`   // Shown before the session
+	Session string        `yaml:"session"` // One of the Session kinds
+	Pattern string        `yaml:"pattern"` // Breathing pattern, for now
+	Set     string        `yaml:"set"`     // Prompt set, for reflect
+	Length  time.Duration `yaml:"length"`  // Length of a sit or noting practice
+	Guide   string        `yaml:"guide"`   // Script, for guide
+}
+
+// Program is a sequence of days
+type Program struct {
+	Name        string
+	Title       string
+	Description string
+	Days        []Day
+	Source      string // File the program was loaded from; empty for built-in programs
+}
+
+// Args returns the zenta command that runs the day's session, such as
+// ["now", "--pattern", "calm"]
+func (d Day) Args() []string {
+	args := []string{d.Session}
+	switch d.Session {
+	case SessionNow:
+		if d.Pattern != "" {
+			args = append(args, "--pattern", d.Pattern)
+		}
+	case SessionSit, SessionNotes:
+		if d.Length > 0 {
+			args = append(args, d.Length.String())
+		}
+	case SessionReflect:
+		if d.Set != "" {
+			args = append(args, "--set", d.Set)
+		}
+	case SessionGuide:
+		args = append(args, d.Guide)
+	}
+	return args
+}
+
+// Validate checks a program for missing text and sessions it can't run
+func (p Program) Validate() error {
+	if p.Title == "" {
+		return fmt.Errorf("missing title")
+	}
+	if len(p.Days) == 0 || len(p.Days) > MaxDays {
+		return fmt.Errorf("a program needs between 1 and %d days", MaxDays)
+	}
+
+	for i, day := range p.Days {
+		if err := day.validate(); err != nil {
+			return fmt.Errorf("day %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a single day
+func (d Day) validate() error {
+	if d.Title == "" {
+		return fmt.Errorf("missing title")
+	}
+	if d.Length < 0 {
+		return fmt.Errorf("length must not be negative")
+	}
+
+	switch d.Session {
+	case SessionNow, SessionAnchor, SessionCount, SessionNotes, SessionSit, SessionReflect:
+	case SessionGuide:
+		if d.Guide == "" {
+			return fmt.Errorf("guide session needs a guide to run")
+		}
+	case "":
+		return fmt.Errorf("missing session")
+	default:
+		return fmt.Errorf("unknown session %q (use now, anchor, count, note-practice, sit, reflect or guide)", d.Session)
+	}
+	return nil
+}
+
+// BuiltinPrograms returns the programs that ship with zenta
+func BuiltinPrograms() []Program {
+	return append([]Program(nil), builtinPrograms...)
+}
+
+// builtinPrograms are the programs compiled into the binary
+var builtinPrograms = []Program{
+	{
+		Name:        "intro-7",
+		Title:       "🌱  Seven Days of Presence",
+		Description: "A gentle first week: breathing, rhythm, counting, reflection and stillness.",
+		Days: []Day{
+			{Title: "Three breaths", Intro: "Everything starts with noticing the breath. Just follow the circle.", Session: SessionNow},
+			{Title: "A longer exhale", Intro: "Breathing out longer than you breathe in tells your body it's safe.", Session: SessionNow, Pattern: "calm"},
+			{Title: "Your own rhythm", Intro: "Today you lead. Press space to switch between breathing in and out.", Session: SessionAnchor},
+			{Title: "Counting breaths", Intro: "Count each exhale to ten. When you lose count, that's the practice: begin again.", Session: SessionCount},
+			{Title: "Looking back", Intro: "A few quiet questions about your day. There are no right answers.", Session: SessionReflect, Set: "evening"},
+			{Title: "Five minutes of stillness", Intro: "Just sit. A bell begins and a bell ends.", Session: SessionSit, Length: 5 * time.Minute},
+			{Title: "The whole body", Intro: "A guided scan from your feet to the top of your head.", Session: SessionGuide, Guide: "body-scan"},
+		},
+	},
+}
@@ -0,0 +1,110 @@
+package program
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/e6a5/zenta/internal/testutil"
+)
+
+func TestBuiltinProgramsAreValid(t *testing.T) {
+	for _, p := range BuiltinPrograms() {
+		if err := p.Validate(); err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+		}
+	}
+}
+
+func TestDayArgs(t *testing.T) {
+	testCases := []struct {
+		day  Day
+		want []string
+	}{
+		{Day{Session: SessionNow}, []string{"now"}},
+		{Day{Session: SessionNow, Pattern: "calm"}, []string{"now", "--pattern", "calm"}},
+		{Day{Session: SessionSit, Length: 5 * time.Minute}, []string{"sit", "5m0s"}},
+		{Day{Session: SessionReflect, Set: "morning"}, []string{"reflect", "--set", "morning"}},
+		{Day{Session: SessionGuide, Guide: "body-scan"}, []string{"guide", "body-scan"}},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.day.Args(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Args() = %v, want %v", got, tc.want)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := testutil.WriteFile(t, dir, "team.yaml", `title: Team Week
+description: Five workdays.
+days:
+  - title: Arrive
+    session: now
+    pattern: coherent
+  - title: Sit
+    intro: Ten quiet minutes.
+    session: sit
+    length: 10m
+`)
+
+	p, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if p.Name != "team" || len(p.Days) != 2 || p.Days[1].Length != 10*time.Minute || p.Days[0].Pattern != "coherent" {
+		t.Errorf("Unexpected program %+v", p)
+	}
+
+	for _, tc := range []struct{ content, want string }{
+		{"title: T\n", "between 1 and"},
+		{"title: T\ndays:\n  - title: D\n", "day 1: missing session"},
+		{"title: T\ndays:\n  - title: D\n    session: dance\n", `unknown session "dance"`},
+		{"title: T\ndays:\n  - title: D\n    session: guide\n", "needs a guide"},
+		{"title: T\ndays:\n  - title: D\n    session: now\n    cycles: 3\n", "field cycles not found"},
+	} {
+		path := testutil.WriteFile(t, dir, "bad.yaml", tc.content)
+		if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Expected an error containing %q for %q, got %v", tc.want, tc.content, err)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "program.json")
+	if p, err := LoadProgress(path); err != nil || p != nil {
+		t.Fatalf("Expected no progress yet, got %+v, %v", p, err)
+	}
+
+	monday := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+	p := &Progress{Program: "intro-7", Started: monday}
+	if p.Day() != 1 || p.DoneToday(monday) {
+		t.Errorf("Expected day 1 to be open, got day %d", p.Day())
+	}
+
+	p.Complete(monday.Add(time.Hour))
+	if p.Day() != 2 || !p.DoneToday(monday.Add(10*time.Hour)) {
+		t.Error("Expected day 2 to stay locked for the rest of Monday")
+	}
+	if p.DoneToday(monday.AddDate(0, 0, 1)) {
+		t.Error("Expected day 2 to unlock on Tuesday")
+	}
+
+	if err := p.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProgress(path)
+	if err != nil || loaded.Program != "intro-7" || len(loaded.Completed) != 1 || loaded.Finished(7) {
+		t.Errorf("Unexpected progress %+v, %v", loaded, err)
+	}
+
+	if err := Leave(path); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := LoadProgress(path); p != nil {
+		t.Error("Expected the progress to be gone")
+	}
+}
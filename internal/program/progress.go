@@ -0,0 +1,69 @@
+package program
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// Progress is how far the user has come through a program. A day unlocks
+// the calendar day after the one before it was done.
+type Progress struct {
+	Program   string      `json:"program"` // The program's name, or the path it was loaded from
+	Started   time.Time   `json:"started"`
+	Completed []time.Time `json:"completed"` // When each day was done, in order
+}
+
+// LoadProgress reads the progress at path. It returns nil without an
+// error when the user isn't in a program.
+func LoadProgress(path string) (*Progress, error) {
+	var p Progress
+	found, err := storage.ReadJSON(path, &p)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save writes the progress to path
+func (p *Progress) Save(path string) error {
+	return storage.WriteJSON(path, p)
+}
+
+// Leave forgets the progress at path
+func Leave(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Day returns the day to do next, counting from one
+func (p *Progress) Day() int {
+	return len(p.Completed) + 1
+}
+
+// DoneToday reports whether a day was already done on now's calendar day,
+// so the next one is still locked
+func (p *Progress) DoneToday(now time.Time) bool {
+	if len(p.Completed) == 0 {
+		return false
+	}
+	last := p.Completed[len(p.Completed)-1].In(now.Location())
+	y1, m1, d1 := last.Date()
+	y2, m2, d2 := now.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// Complete records the next day as done at now
+func (p *Progress) Complete(now time.Time) {
+	p.Completed = append(p.Completed, now)
+}
+
+// Finished reports whether every day of a program with days days is done
+func (p *Progress) Finished(days int) bool {
+	return len(p.Completed) >= days
+}
@@ -0,0 +1,174 @@
+package quotes
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRuleWeight is how much more likely a preferred quote becomes
+const DefaultRuleWeight = 3
+
+// Context describes when and after what a quote is being shown
+type Context struct {
+	Time    time.Time
+	Session string // e.g. "quick", "now", "extended", "anchor", "guide", "reflect"
+}
+
+// Rule makes quotes with certain tags more likely in a matching context.
+// Empty conditions match everything.
+type Rule struct {
+	Name     string   `yaml:"name"`
+	Hours    string   `yaml:"hours,omitempty"`    // Inclusive range such as "5-11", or "21-4" across midnight
+	Sessions []string `yaml:"sessions,omitempty"` // Session types the rule applies to
+	Prefer   []string `yaml:"prefer"`             // Tags to favor
+	Weight   int      `yaml:"weight,omitempty"`   // Multiplier, DefaultRuleWeight when zero
+}
+
+// DefaultRules favor fresh starts in the morning, letting go in the
+// evening and after reflection prompts, and rhythm after finding your own
+// pace in anchor
+var DefaultRules = []Rule{
+	{Name: "morning", Hours: "5-11", Prefer: []string{"beginning"}},
+	{Name: "evening", Hours: "18-23", Prefer: []string{"letting go"}},
+	{Name: "reflect", Sessions: []string{"reflect"}, Prefer: []string{"letting go"}},
+	{Name: "anchor", Sessions: []string{"anchor"}, Prefer: []string{"rhythm"}},
+	{Name: "extended", Sessions: []string{"extended"}, Prefer: []string{"stillness", "patience"}},
+}
+
+// Matches reports whether the rule applies in ctx
+func (r Rule) Matches(ctx Context) bool {
+	if r.Hours != "" {
+		from, to, err := parseHours(r.Hours)
+		if err != nil {
+			return false
+		}
+		hour := ctx.Time.Hour()
+		if from <= to && (hour < from || hour > to) {
+			return false
+		}
+		if from > to && hour < from && hour > to {
+			return false // Outside a range that wraps past midnight
+		}
+	}
+
+	if len(r.Sessions) > 0 {
+		found := false
+		for _, s := range r.Sessions {
+			if strings.EqualFold(s, ctx.Session) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContextWeight returns the relative selection weight of q in ctx: each
+// matching rule that prefers one of the quote's tags multiplies it.
+func ContextWeight(q Quote, ctx Context, rules []Rule) int {
+	weight := 1
+	for _, r := range rules {
+		if !r.Matches(ctx) || !hasAnyTag(q, r.Prefer) {
+			continue
+		}
+		if r.Weight > 0 {
+			weight *= r.Weight
+		} else {
+			weight *= DefaultRuleWeight
+		}
+	}
+	return weight
+}
+
+// SelectForContext picks the index of a quote from candidates, weighted by
+// the rules matching ctx. It is deterministic for a given rng state.
+// Candidates must not be empty.
+func SelectForContext(candidates []Quote, ctx Context, rules []Rule, rng *rand.Rand) int {
+	weights := make([]int, len(candidates))
+	total := 0
+	for i, q := range candidates {
+		weights[i] = ContextWeight(q, ctx, rules)
+		total += weights[i]
+	}
+
+	pick := rng.Intn(total)
+	for i, w := range weights {
+		pick -= w
+		if pick < 0 {
+			return i
+		}
+	}
+	return len(candidates) - 1
+}
+
+// LoadRules reads context rules from a YAML file. A missing file yields
+// DefaultRules; a present file replaces them entirely.
+func LoadRules(path string) ([]Rule, error) {
+	// #nosec G304 -- reading the user's own rules file
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultRules, nil
+	}
+	if err != nil {
+		return DefaultRules, err
+	}
+
+	var rules []Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return DefaultRules, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i, r := range rules {
+		if err := r.validate(); err != nil {
+			return DefaultRules, fmt.Errorf("%s: rule %d: %w", path, i+1, err)
+		}
+	}
+	return rules, nil
+}
+
+// validate checks a rule for mistakes that would silently disable it
+func (r Rule) validate() error {
+	if len(r.Prefer) == 0 {
+		return fmt.Errorf("no tags to prefer")
+	}
+	if r.Weight < 0 {
+		return fmt.Errorf("weight must be positive")
+	}
+	if r.Hours != "" {
+		if _, _, err := parseHours(r.Hours); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseHours parses an inclusive hour range like "5-11"
+func parseHours(s string) (int, int, error) {
+	fromText, toText, found := strings.Cut(s, "-")
+	from, errFrom := strconv.Atoi(strings.TrimSpace(fromText))
+	to, errTo := strconv.Atoi(strings.TrimSpace(toText))
+	if !found || errFrom != nil || errTo != nil || from < 0 || from > 23 || to < 0 || to > 23 {
+		return 0, 0, fmt.Errorf("invalid hours %q (expected a range like 5-11)", s)
+	}
+	return from, to, nil
+}
+
+// hasAnyTag reports whether q carries any of tags
+func hasAnyTag(q Quote, tags []string) bool {
+	for _, tag := range tags {
+		if q.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package quotes
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func at(hour int) time.Time {
+	return time.Date(2025, 7, 15, hour, 30, 0, 0, time.Local)
+}
+
+func TestRuleMatches(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule Rule
+		ctx  Context
+		want bool
+	}{
+		{"morning inside", Rule{Hours: "5-11"}, Context{Time: at(7)}, true},
+		{"morning last hour", Rule{Hours: "5-11"}, Context{Time: at(11)}, true},
+		{"morning outside", Rule{Hours: "5-11"}, Context{Time: at(12)}, false},
+		{"overnight late", Rule{Hours: "21-4"}, Context{Time: at(23)}, true},
+		{"overnight early", Rule{Hours: "21-4"}, Context{Time: at(3)}, true},
+		{"overnight outside", Rule{Hours: "21-4"}, Context{Time: at(12)}, false},
+		{"session", Rule{Sessions: []string{"anchor"}}, Context{Session: "anchor"}, true},
+		{"other session", Rule{Sessions: []string{"anchor"}}, Context{Session: "now"}, false},
+		{"both conditions", Rule{Hours: "18-23", Sessions: []string{"reflect"}}, Context{Time: at(20), Session: "reflect"}, true},
+		{"unconditional", Rule{}, Context{Time: at(12), Session: "now"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rule.Matches(tc.ctx); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContextWeight(t *testing.T) {
+	beginning := Quote{Text: "Every moment is a fresh beginning.", Tags: []string{"beginning"}}
+	plain := Quote{Text: "Simplicity is the ultimate sophistication."}
+	morning := Context{Time: at(7), Session: "now"}
+
+	if w := ContextWeight(beginning, morning, DefaultRules); w != DefaultRuleWeight {
+		t.Errorf("Expected morning to favor beginnings, got weight %d", w)
+	}
+	if w := ContextWeight(plain, morning, DefaultRules); w != 1 {
+		t.Errorf("Expected untagged quote weight 1, got %d", w)
+	}
+	if w := ContextWeight(beginning, Context{Time: at(20)}, DefaultRules); w != 1 {
+		t.Errorf("Expected no morning preference in the evening, got %d", w)
+	}
+
+	custom := []Rule{{Prefer: []string{"beginning"}, Weight: 10}}
+	if w := ContextWeight(beginning, morning, custom); w != 10 {
+		t.Errorf("Expected custom weight 10, got %d", w)
+	}
+}
+
+func TestSelectForContext(t *testing.T) {
+	candidates := []Quote{
+		{Text: "one"},
+		{Text: "two", Tags: []string{"rhythm"}},
+		{Text: "three"},
+	}
+	ctx := Context{Time: at(14), Session: "anchor"}
+	rng := rand.New(rand.NewSource(42))
+
+	counts := make([]int, len(candidates))
+	for i := 0; i < 5000; i++ {
+		counts[SelectForContext(candidates, ctx, DefaultRules, rng)]++
+	}
+
+	// Expected share is 3/5 for the rhythm quote
+	if counts[1] < 2700 || counts[1] > 3300 {
+		t.Errorf("Expected rhythm quote to be picked ~60%% of the time, got %v", counts)
+	}
+
+	// The same seed gives the same choice
+	a := SelectForContext(candidates, ctx, DefaultRules, rand.New(rand.NewSource(9)))
+	b := SelectForContext(candidates, ctx, DefaultRules, rand.New(rand.NewSource(9)))
+	if a != b {
+		t.Error("Expected selection to be deterministic for a given seed")
+	}
+}
+
+func TestNextInRotationPrefersContext(t *testing.T) {
+	qs := testService("one", "two", "three", "four", "five", "six")
+	qs.Add("test", Quote{Text: "letting go", Tags: []string{"letting go"}})
+	rules := []Rule{{Sessions: []string{"reflect"}, Prefer: []string{"letting go"}, Weight: 100}}
+	ctx := Context{Session: "reflect"}
+
+	firsts := 0
+	for seed := int64(0); seed < 20; seed++ {
+		state := &State{}
+		if qs.NextInRotation(state, rand.New(rand.NewSource(seed)), ctx, rules).Text == "letting go" {
+			firsts++
+		}
+	}
+	if firsts < 15 {
+		t.Errorf("Expected the preferred quote to usually come first, got %d/20", firsts)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+
+	rules, err := LoadRules(filepath.Join(dir, "missing.yaml"))
+	if err != nil || len(rules) != len(DefaultRules) {
+		t.Errorf("Expected defaults for a missing file, got %d rules (%v)", len(rules), err)
+	}
+
+	path := filepath.Join(dir, "rules.yaml")
+	content := "- name: lunch\n  hours: 12-13\n  prefer: [calm]\n  weight: 5\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadRules(path)
+	if err != nil || len(rules) != 1 || rules[0].Weight != 5 {
+		t.Errorf("Expected custom rule, got %+v (%v)", rules, err)
+	}
+
+	for _, bad := range []string{
+		"- name: broken\n  hours: 25-3\n  prefer: [calm]\n",
+		"- name: empty\n  hours: 5-11\n",
+		"not: [a, list]\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadRules(path); err == nil {
+			t.Errorf("Expected error for invalid rules:\n%s", bad)
+		}
+	}
+}
@@ -4,94 +4,186 @@ package quotes
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
-	"unicode"
+
+	"golang.org/x/term"
+
+	"github.com/e6a5/zenta/internal/textwidth"
+	"github.com/e6a5/zenta/internal/tty"
+)
+
+// Layout constants for quote display, in terminal columns
+const (
+	leftPadding  = 4  // Left margin, matching the breathing display
+	MaxWrapWidth = 50 // Comfortable reading width for quotes
+	MinWrapWidth = 20 // Narrowest width worth wrapping to
 )
 
+// settleDelay lets stray key presses reach the reader before they're discarded
+const settleDelay = 30 * time.Millisecond
+
 // DisplayBeautifully displays a quote with beautiful formatting and typing animation
-func DisplayBeautifully(quote string) {
-	emoji, quoteText := parseQuoteEmoji(quote)
-	lines := wrapQuoteText(quoteText)
-	renderQuoteWithoutBox(lines, emoji)
+func DisplayBeautifully(quote Quote) {
+	Display(quote, DefaultDisplayOptions())
 }
 
-// parseQuoteEmoji extracts emoji from quote if present
-func parseQuoteEmoji(quote string) (string, string) {
-	parts := strings.Fields(quote)
-	if len(parts) > 0 && isEmoji(rune(parts[0][0])) {
-		return parts[0], strings.Join(parts[1:], " ")
+// Display shows a quote in the given style. Any key press finishes the
+// quote immediately. When stdout isn't a terminal, as in pipes or
+// captured panes, the animation is skipped entirely.
+func Display(quote Quote, opts DisplayOptions) {
+	style, ok := lineStyles[opts.Style]
+	if !ok || opts.Speed <= 0 || !tty.IsTerminal(os.Stdout) {
+		style = instantLine
+	}
+
+	var out io.Writer = os.Stdout
+	p := &pacer{}
+
+	// Listen for a key to skip the animation, when there is one to skip
+	if opts.Speed > 0 && tty.IsInteractive() {
+		if restore, err := tty.Raw(); err == nil {
+			defer restore()
+			p.skip = freshKeys()
+			out = tty.NewlineWriter(os.Stdout)
+		}
 	}
-	return "💭", quote
+
+	emoji := quote.DisplayEmoji()
+	lines := wrapQuoteText(quote.Text, wrapWidth(terminalWidth(), emoji))
+	renderQuoteWithoutBox(out, lines, emoji, quote.Attribution(), style, opts.Speed, p)
+}
+
+// freshKeys starts listening for keys, then lets those typed during the
+// breathing session settle and ignores them, so only a new press skips
+func freshKeys() <-chan byte {
+	keys := tty.Keys()
+	time.Sleep(settleDelay)
+	tty.Drain()
+	return keys
 }
 
-// wrapQuoteText wraps text to fit within terminal width
-func wrapQuoteText(quoteText string) []string {
-	maxWidth := 50 // Maximum line width for quotes
-	words := strings.Fields(quoteText)
+// terminalWidth returns the width of stdout, or 0 when it isn't a terminal
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// wrapWidth derives the quote width from the terminal width, leaving room
+// for the padding and emoji column. Unknown widths use MaxWrapWidth.
+func wrapWidth(termWidth int, emoji string) int {
+	if termWidth <= 0 {
+		return MaxWrapWidth
+	}
+
+	width := termWidth - leftPadding - textwidth.String(emoji) - 2
+	if width > MaxWrapWidth {
+		return MaxWrapWidth
+	}
+	if width < MinWrapWidth {
+		return MinWrapWidth
+	}
+	return width
+}
+
+// wrapQuoteText wraps text to fit within maxWidth terminal columns.
+// Widths are measured per grapheme, so accented, CJK and emoji text wrap
+// where they visually end. Words wider than a line, such as unspaced CJK
+// sentences, are broken between graphemes.
+func wrapQuoteText(quoteText string, maxWidth int) []string {
 	var lines []string
-	var currentLine []string
-	currentLength := 0
-
-	for _, word := range words {
-		wordLength := len(word)
-		if currentLength+wordLength+len(currentLine) > maxWidth && len(currentLine) > 0 {
-			lines = append(lines, strings.Join(currentLine, " "))
-			currentLine = []string{word}
-			currentLength = wordLength
-		} else {
-			currentLine = append(currentLine, word)
-			currentLength += wordLength
+	var currentLine strings.Builder
+	currentWidth := 0
+
+	flush := func() {
+		lines = append(lines, currentLine.String())
+		currentLine.Reset()
+		currentWidth = 0
+	}
+
+	for _, word := range strings.Fields(quoteText) {
+		wordWidth := textwidth.String(word)
+
+		// Start a new line when the word doesn't fit after a space
+		if currentWidth > 0 && currentWidth+1+wordWidth > maxWidth {
+			flush()
+		}
+
+		if wordWidth <= maxWidth {
+			if currentWidth > 0 {
+				currentLine.WriteByte(' ')
+				currentWidth++
+			}
+			currentLine.WriteString(word)
+			currentWidth += wordWidth
+			continue
+		}
+
+		// Break an oversized word between graphemes
+		if currentWidth > 0 {
+			currentLine.WriteByte(' ')
+			currentWidth++
+		}
+		for _, cluster := range textwidth.Graphemes(word) {
+			w := textwidth.Cluster(cluster)
+			if currentWidth+w > maxWidth && currentWidth > 0 {
+				flush()
+			}
+			currentLine.WriteString(cluster)
+			currentWidth += w
 		}
 	}
 
-	if len(currentLine) > 0 {
-		lines = append(lines, strings.Join(currentLine, " "))
+	if currentWidth > 0 {
+		flush()
 	}
 
 	return lines
 }
 
+// attributionLine right-aligns "— attribution" under the widest quote line
+func attributionLine(lines []string, attribution string) string {
+	text := "— " + attribution
+
+	width := 0
+	for _, line := range lines {
+		if w := textwidth.String(line); w > width {
+			width = w
+		}
+	}
+
+	indent := width - textwidth.String(text)
+	if indent < 0 {
+		indent = 0
+	}
+	return strings.Repeat(" ", indent) + text
+}
+
 // renderQuoteWithoutBox renders a quote simply without box borders
-func renderQuoteWithoutBox(lines []string, emoji string) {
-	leftPadding := 4
+func renderQuoteWithoutBox(w io.Writer, lines []string, emoji, attribution string, style lineStyle, speed time.Duration, p *pacer) {
 	padding := strings.Repeat(" ", leftPadding)
+	// Continuation lines align with the text after the emoji and its space
+	indent := strings.Repeat(" ", textwidth.String(emoji)+1)
 
-	fmt.Println() // Add spacing before quote
+	fmt.Fprintln(w) // Add spacing before quote
 
 	for i, line := range lines {
-		fmt.Print(padding)
-
+		prefix := padding + indent
 		if i == 0 {
-			fmt.Printf("%s ", emoji)
-		} else {
-			fmt.Print("  ") // Two spaces to align with emoji width
+			prefix = padding + emoji + " "
 		}
-
-		// Type out the line character by character - slower for zen effect
-		for _, char := range line {
-			fmt.Print(string(char))
-			if !unicode.IsSpace(char) {
-				time.Sleep(50 * time.Millisecond) // Slower, more contemplative typing
-			} else {
-				time.Sleep(20 * time.Millisecond) // Brief pause for spaces
-			}
-		}
-
-		fmt.Println()
+		style(w, prefix, line, speed, p)
 	}
 
-	fmt.Println() // Add spacing after quote
-}
+	// The attribution appears all at once, on its own line
+	if attribution != "" {
+		fmt.Fprintf(w, "%s%s%s\n", padding, indent, attributionLine(lines, attribution))
+	}
 
-// isEmoji checks if a rune is an emoji character
-func isEmoji(r rune) bool {
-	// Simple check for emoji ranges
-	return (r >= 0x1F600 && r <= 0x1F64F) || // Emoticons
-		(r >= 0x1F300 && r <= 0x1F5FF) || // Misc Symbols
-		(r >= 0x1F680 && r <= 0x1F6FF) || // Transport
-		(r >= 0x2600 && r <= 0x26FF) || // Misc symbols
-		(r >= 0x2700 && r <= 0x27BF) || // Dingbats
-		(r >= 0xFE00 && r <= 0xFE0F) || // Variation Selectors
-		(r >= 0x1F900 && r <= 0x1F9FF) // Supplemental Symbols
+	fmt.Fprintln(w) // Add spacing after quote
 }
@@ -0,0 +1,180 @@
+package quotes
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/e6a5/zenta/internal/textwidth"
+)
+
+func TestWrapQuoteText(t *testing.T) {
+	testCases := []struct {
+		name  string
+		text  string
+		width int
+	}{
+		{"english", "You have power over your mind—not outside events. Realize this, and you will find strength.", 50},
+		{"french", "Là où est ton trésor, là aussi sera ton cœur. Sois présent à chaque souffle, à chaque pensée.", 30},
+		{"vietnamese", "Hãy thở vào và biết rằng mình đang thở vào. Hãy thở ra và mỉm cười.", 24},
+		{"japanese", "静かな心は、すべてを映す鏡のようなものである。", 20},
+		{"chinese with spaces", "知人者智， 自知者明。 胜人者有力， 自胜者强。", 12},
+		{"emoji", "Breathe in 🌸 breathe out 🕊️ and smile 🙂 together 👨‍👩‍👧", 16},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := wrapQuoteText(tc.text, tc.width)
+			if len(lines) < 2 {
+				t.Errorf("Expected text to wrap at width %d, got %q", tc.width, lines)
+			}
+
+			for _, line := range lines {
+				if w := textwidth.String(line); w > tc.width {
+					t.Errorf("Line %q is %d columns, wider than %d", line, w, tc.width)
+				}
+			}
+
+			// Wrapping must not lose or reorder any text
+			joined := strings.ReplaceAll(strings.Join(lines, ""), " ", "")
+			if want := strings.ReplaceAll(tc.text, " ", ""); joined != want {
+				t.Errorf("Wrapped text %q doesn't match original %q", joined, want)
+			}
+		})
+	}
+}
+
+func TestWrapQuoteTextFillsAccentedLines(t *testing.T) {
+	// Byte-length wrapping would break this 20-column line early
+	lines := wrapQuoteText("Ça éveille l'âme doucement", 26)
+	if len(lines) != 1 {
+		t.Errorf("Expected accented text to fit on one line, got %q", lines)
+	}
+}
+
+func TestWrapWidth(t *testing.T) {
+	testCases := []struct {
+		termWidth int
+		want      int
+	}{
+		{0, MaxWrapWidth},
+		{200, MaxWrapWidth},
+		{48, 40},
+		{10, MinWrapWidth},
+	}
+
+	for _, tc := range testCases {
+		if got := wrapWidth(tc.termWidth, "🌸"); got != tc.want {
+			t.Errorf("wrapWidth(%d) = %d, want %d", tc.termWidth, got, tc.want)
+		}
+	}
+}
+
+func TestAttributionLine(t *testing.T) {
+	lines := []string{"静かな心は、すべてを映す", "鏡のようなもの"}
+	line := attributionLine(lines, "老子")
+
+	if got, want := textwidth.String(line), textwidth.String(lines[0]); got != want {
+		t.Errorf("Expected attribution to end at column %d, got %d (%q)", want, got, line)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for _, name := range []string{"typewriter", "Fade", " instant "} {
+		if _, err := ParseStyle(name); err != nil {
+			t.Errorf("ParseStyle(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := ParseStyle("sparkle"); err == nil {
+		t.Error("Expected an error for an unknown style")
+	}
+}
+
+func TestLineStylesWriteWholeLine(t *testing.T) {
+	for style, render := range lineStyles {
+		var buf bytes.Buffer
+		render(&buf, "    🌸 ", "Breathe in.", 0, &pacer{})
+
+		if !strings.HasSuffix(buf.String(), "    🌸 Breathe in.\n") {
+			t.Errorf("Style %s wrote %q", style, buf.String())
+		}
+	}
+}
+
+func TestPacerSkipsOnKeyPress(t *testing.T) {
+	skip := make(chan byte, 1)
+	skip <- ' '
+	p := &pacer{skip: skip}
+
+	start := time.Now()
+	p.wait(time.Second)
+	p.wait(time.Second)
+
+	if !p.skipped {
+		t.Error("Expected a key press to skip the animation")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected skipped waits to return at once, took %v", elapsed)
+	}
+}
+
+func TestPacerIgnoresClosedInput(t *testing.T) {
+	skip := make(chan byte)
+	close(skip)
+	p := &pacer{skip: skip}
+
+	p.wait(time.Millisecond)
+
+	if p.skipped {
+		t.Error("Expected closed input not to skip the animation")
+	}
+}
+
+// keyboard stands in for stdin. The shared key reader keeps reading it
+// once started, so it lasts the whole test binary.
+var keyboard = sync.OnceValue(func() *os.File {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+	os.Stdin = r
+	return w
+})
+
+func TestFreshKeysIgnoresWaitingKeys(t *testing.T) {
+	w := keyboard()
+
+	// A key pressed during the breathing session, before the quote
+	if _, err := w.Write([]byte{' '}); err != nil {
+		t.Fatal(err)
+	}
+	p := &pacer{skip: freshKeys()}
+	p.wait(50 * time.Millisecond)
+	if p.skipped {
+		t.Fatal("Expected a key waiting before the quote not to skip it")
+	}
+
+	if _, err := w.Write([]byte{' '}); err != nil {
+		t.Fatal(err)
+	}
+	p.wait(time.Second)
+	if !p.skipped {
+		t.Error("Expected a new key press to skip the quote")
+	}
+}
+
+func TestRenderSkippedFadeIsPlain(t *testing.T) {
+	var buf bytes.Buffer
+	p := &pacer{skipped: true}
+	renderQuoteWithoutBox(&buf, []string{"Be still."}, "🌸", "Lao Tzu", fadeLine, DefaultSpeed, p)
+
+	if strings.Contains(buf.String(), dim) {
+		t.Errorf("Expected a skipped fade to print without dimming, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "— Lao Tzu") {
+		t.Errorf("Expected the attribution, got %q", buf.String())
+	}
+}
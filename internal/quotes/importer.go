@@ -0,0 +1,209 @@
+package quotes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// DefaultImportLines is the most wrapped lines an imported quote may take,
+// keeping it calm to read beside a breathing session
+const DefaultImportLines = 4
+
+// strfile header flags, from fortune's strfile(8)
+const (
+	strfileHeaderSize = 24
+	strfileRandom     = 0x1 // Offsets shuffled with strfile -r
+	strfileOrdered    = 0x2 // Offsets sorted by text with strfile -o
+	strfileRotated    = 0x4
+)
+
+// ImportResult summarizes which candidate quotes an import kept
+type ImportResult struct {
+	Added      []Quote
+	Duplicates int // Already known, or repeated within the import
+	TooLong    int // Wouldn't fit the quote layout
+	Empty      int // No text left after parsing
+}
+
+// ParseFortune parses a fortune(6) database: quotes separated by lines
+// holding a single "%". When the strfile index (the .dat file) is given,
+// its offsets are used and ROT13-encoded databases are decoded. Trailing
+// "-- Author" lines become the quote's attribution.
+func ParseFortune(data, index []byte) ([]Quote, error) {
+	var chunks []string
+	if len(index) > 0 {
+		var err error
+		if chunks, err = fortuneChunksFromIndex(data, index); err != nil {
+			return nil, err
+		}
+	} else {
+		chunks = fortuneChunks(data)
+	}
+
+	quotes := make([]Quote, 0, len(chunks))
+	for _, chunk := range chunks {
+		quotes = append(quotes, parseFortuneEntry(chunk))
+	}
+	return quotes, nil
+}
+
+// ParseLines parses one quote per line, in the "emoji text - author" form,
+// skipping blank lines and # comments
+func ParseLines(data []byte) []Quote {
+	var quotes []Quote
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		quotes = append(quotes, Parse(line))
+	}
+	return quotes
+}
+
+// FilterImport keeps candidates that aren't already known, aren't repeated
+// within the import, and wrap to at most maxLines lines
+func (qs *QuoteService) FilterImport(candidates []Quote, maxLines int) ImportResult {
+	var result ImportResult
+
+	seen := make(map[string]bool)
+	for _, q := range qs.GetAllQuotes() {
+		seen[dedupKey(q.Text)] = true
+	}
+
+	for _, q := range candidates {
+		key := dedupKey(q.Text)
+		switch {
+		case key == "":
+			result.Empty++
+		case seen[key]:
+			result.Duplicates++
+		case maxLines > 0 && len(wrapQuoteText(q.Text, MaxWrapWidth)) > maxLines:
+			result.TooLong++
+		default:
+			seen[key] = true
+			result.Added = append(result.Added, q)
+		}
+	}
+
+	return result
+}
+
+// dedupKey reduces text to lowercase letters and digits, so quotes that
+// differ only in punctuation, case or spacing count as duplicates
+func dedupKey(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fortuneChunks splits a fortune file on its "%" delimiter lines
+func fortuneChunks(data []byte) []string {
+	var chunks []string
+	var current []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimRight(line, "\r") == "%" {
+			chunks = append(chunks, strings.Join(current, "\n"))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n"))
+	}
+	return chunks
+}
+
+// fortuneChunksFromIndex slices a fortune file using its strfile index.
+// The offsets may be shuffled (strfile -r) or sorted (strfile -o), so each
+// fortune is read from its offset up to the next delimiter line rather
+// than to the following offset.
+func fortuneChunksFromIndex(data, index []byte) ([]string, error) {
+	if len(index) < strfileHeaderSize {
+		return nil, fmt.Errorf("strfile index is too short")
+	}
+
+	numstr := binary.BigEndian.Uint32(index[4:8])
+	flags := binary.BigEndian.Uint32(index[16:20])
+	delim := string(index[20])
+
+	offsets := index[strfileHeaderSize:]
+	if uint64(len(offsets)) < uint64(numstr)*4 {
+		return nil, fmt.Errorf("strfile index lists %d quotes but holds fewer offsets", numstr)
+	}
+
+	chunks := make([]string, 0, numstr)
+	for i := uint32(0); i < numstr; i++ {
+		start := binary.BigEndian.Uint32(offsets[i*4:])
+		if int(start) > len(data) {
+			return nil, fmt.Errorf("strfile index offset %d is out of range", i)
+		}
+
+		chunk := fortuneAt(data[start:], delim)
+		if flags&strfileRotated != 0 {
+			chunk = rot13(chunk)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// fortuneAt returns the fortune at the start of data, up to the next line
+// holding only the delimiter
+func fortuneAt(data []byte, delim string) string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimRight(line, "\r") == delim {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseFortuneEntry unwraps a fortune and lifts a trailing "-- Author" line
+// into the attribution
+func parseFortuneEntry(chunk string) Quote {
+	lines := strings.Split(strings.TrimSpace(chunk), "\n")
+
+	var q Quote
+	if n := len(lines); n > 1 {
+		last := strings.TrimSpace(lines[n-1])
+		if strings.HasPrefix(last, "--") || strings.HasPrefix(last, "—") {
+			q.Author, q.Source, _ = strings.Cut(strings.TrimSpace(strings.TrimLeft(last, "-— ")), ", ")
+			lines = lines[:n-1]
+		}
+	}
+
+	// Fortunes are hard-wrapped for 80 columns; zenta rewraps them
+	q.Text = strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+	return q
+}
+
+// rot13 decodes the letter rotation used by offensive fortune databases
+func rot13(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+13)%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+13)%26
+		}
+		return r
+	}, s)
+}
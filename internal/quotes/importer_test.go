@@ -0,0 +1,140 @@
+package quotes
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+const fortuneFile = `The quieter you become,
+the more you are able to hear.
+%
+Simplicity is the ultimate
+sophistication.
+		-- Leonardo da Vinci
+%
+Knowing others is intelligence;
+knowing yourself is true wisdom.
+		-- Lao Tzu, Tao Te Ching
+%
+`
+
+// buildIndex writes a strfile index for the given fortune file
+func buildIndex(data string, rotated bool) []byte {
+	var offsets []uint32
+	offsets = append(offsets, 0)
+	for i := 0; i+2 <= len(data); i++ {
+		if data[i:i+2] == "%\n" && (i == 0 || data[i-1] == '\n') {
+			offsets = append(offsets, uint32(i+2))
+		}
+	}
+
+	header := make([]byte, strfileHeaderSize)
+	binary.BigEndian.PutUint32(header[0:], 2)
+	binary.BigEndian.PutUint32(header[4:], uint32(len(offsets)-1))
+	if rotated {
+		binary.BigEndian.PutUint32(header[16:], strfileRotated)
+	}
+	header[20] = '%'
+
+	for _, off := range offsets {
+		header = binary.BigEndian.AppendUint32(header, off)
+	}
+	return header
+}
+
+func TestParseFortune(t *testing.T) {
+	check := func(t *testing.T, quotes []Quote) {
+		t.Helper()
+		if len(quotes) != 3 {
+			t.Fatalf("Expected 3 fortunes, got %d: %+v", len(quotes), quotes)
+		}
+		if quotes[0].Text != "The quieter you become, the more you are able to hear." || quotes[0].Author != "" {
+			t.Errorf("Expected unwrapped text without author, got %+v", quotes[0])
+		}
+		if quotes[1].Author != "Leonardo da Vinci" || quotes[1].Text != "Simplicity is the ultimate sophistication." {
+			t.Errorf("Expected attribution to be lifted out, got %+v", quotes[1])
+		}
+		if quotes[2].Author != "Lao Tzu" || quotes[2].Source != "Tao Te Ching" {
+			t.Errorf("Expected author and source, got %+v", quotes[2])
+		}
+	}
+
+	t.Run("delimiters", func(t *testing.T) {
+		quotes, err := ParseFortune([]byte(fortuneFile), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, quotes)
+	})
+
+	t.Run("strfile index", func(t *testing.T) {
+		quotes, err := ParseFortune([]byte(fortuneFile), buildIndex(fortuneFile, false))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, quotes)
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		rotated := rot13(fortuneFile)
+		quotes, err := ParseFortune([]byte(rotated), buildIndex(rotated, true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, quotes)
+	})
+
+	t.Run("random order", func(t *testing.T) {
+		// strfile -r shuffles the offsets; reverse the three fortunes'
+		index := buildIndex(fortuneFile, false)
+		binary.BigEndian.PutUint32(index[16:], strfileRandom)
+		offsets := index[strfileHeaderSize:]
+		first, last := binary.BigEndian.Uint32(offsets[0:]), binary.BigEndian.Uint32(offsets[8:])
+		binary.BigEndian.PutUint32(offsets[0:], last)
+		binary.BigEndian.PutUint32(offsets[8:], first)
+
+		quotes, err := ParseFortune([]byte(fortuneFile), index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		quotes[0], quotes[2] = quotes[2], quotes[0]
+		check(t, quotes)
+	})
+
+	t.Run("corrupt index", func(t *testing.T) {
+		if _, err := ParseFortune([]byte(fortuneFile), []byte{0, 1, 2}); err == nil {
+			t.Error("Expected error for a truncated index")
+		}
+	})
+}
+
+func TestParseLines(t *testing.T) {
+	quotes := ParseLines([]byte("# comment\n\n🌙 Rest is part of the work.\nShip it, then breathe. - Me\n"))
+	if len(quotes) != 2 || quotes[0].Emoji != "🌙" || quotes[1].Author != "Me" {
+		t.Errorf("Unexpected quotes: %+v", quotes)
+	}
+}
+
+func TestFilterImport(t *testing.T) {
+	qs := New()
+	long := strings.Repeat("Breathe and let the long thought pass by slowly. ", 10)
+
+	candidates := []Quote{
+		{Text: "A brand new thought about the breath."},
+		{Text: "a brand-new thought, about the breath!"},               // Repeat within the import
+		{Text: "the quieter you become the more you are able to hear"}, // Already built in
+		{Text: long},
+		{Text: "  "},
+	}
+
+	result := qs.FilterImport(candidates, DefaultImportLines)
+	if len(result.Added) != 1 || result.Duplicates != 2 || result.TooLong != 1 || result.Empty != 1 {
+		t.Errorf("Unexpected import result: added=%d duplicates=%d tooLong=%d empty=%d",
+			len(result.Added), result.Duplicates, result.TooLong, result.Empty)
+	}
+
+	if result := qs.FilterImport([]Quote{{Text: long}}, 0); len(result.Added) != 1 {
+		t.Error("Expected no length limit when maxLines is 0")
+	}
+}
@@ -0,0 +1,184 @@
+package quotes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadError describes a malformed entry in a user quote file
+type LoadError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// yamlQuote is a single entry in a YAML quote file
+type yamlQuote struct {
+	Text     string   `yaml:"text"`
+	Author   string   `yaml:"author,omitempty"`
+	Source   string   `yaml:"source,omitempty"`
+	Emoji    string   `yaml:"emoji,omitempty"`
+	Tags     []string `yaml:"tags,omitempty,flow"`
+	Language string   `yaml:"language,omitempty"`
+}
+
+// yamlFields lists the keys a YAML quote entry may use
+var yamlFields = map[string]bool{
+	"text": true, "author": true, "source": true, "emoji": true, "tags": true, "language": true,
+}
+
+// LoadDir merges user quote files from dir into the service. Text files
+// (*.txt) hold one quote per line; YAML files (*.yaml, *.yml) hold a list
+// of entries with text, author, source, emoji, tags and language fields. Each file becomes,
+// or extends, the collection named after it. A missing directory is not
+// an error. Malformed entries are skipped and reported with file and line.
+func (qs *QuoteService) LoadDir(dir string) []error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []error{err}
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		ext := filepath.Ext(name)
+
+		var parse func(string, []byte) ([]Quote, []error)
+		switch ext {
+		case ".txt":
+			parse = parseTextQuotes
+		case ".yaml", ".yml":
+			parse = parseYAMLQuotes
+		default:
+			continue
+		}
+
+		// #nosec G304 -- reading the user's own quote directory
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		quotes, fileErrs := parse(path, data)
+		errs = append(errs, fileErrs...)
+		if len(quotes) > 0 {
+			qs.Add(strings.TrimSuffix(name, ext), quotes...)
+		}
+	}
+
+	return errs
+}
+
+// parseTextQuotes reads one quote per line, skipping blanks and # comments
+func parseTextQuotes(path string, data []byte) ([]Quote, []error) {
+	var quotes []Quote
+	var errs []error
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		quote := Parse(text)
+		if quote.Text == "" {
+			errs = append(errs, &LoadError{path, line, "quote has no text"})
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", path, err))
+	}
+
+	return quotes, errs
+}
+
+// parseYAMLQuotes reads a YAML list of quote entries
+func parseYAMLQuotes(path string, data []byte) ([]Quote, []error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", path, err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // Empty file
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, []error{&LoadError{path, root.Line, "expected a list of quotes"}}
+	}
+
+	var quotes []Quote
+	var errs []error
+
+	for _, item := range root.Content {
+		if item.Kind != yaml.MappingNode {
+			errs = append(errs, &LoadError{path, item.Line, "expected an entry with a text field"})
+			continue
+		}
+
+		if err := checkYAMLFields(path, item); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var q yamlQuote
+		if err := item.Decode(&q); err != nil {
+			errs = append(errs, &LoadError{path, item.Line, err.Error()})
+			continue
+		}
+		if strings.TrimSpace(q.Text) == "" {
+			errs = append(errs, &LoadError{path, item.Line, "quote has no text"})
+			continue
+		}
+
+		quotes = append(quotes, Quote{
+			Text:     strings.TrimSpace(q.Text),
+			Author:   strings.TrimSpace(q.Author),
+			Source:   strings.TrimSpace(q.Source),
+			Emoji:    strings.TrimSpace(q.Emoji),
+			Tags:     q.Tags,
+			Language: q.Language,
+		})
+	}
+
+	return quotes, errs
+}
+
+// checkYAMLFields rejects unknown keys, which are usually typos
+func checkYAMLFields(path string, item *yaml.Node) error {
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key := item.Content[i]
+		if !yamlFields[key.Value] {
+			return &LoadError{path, key.Line, fmt.Sprintf("unknown field %q", key.Value)}
+		}
+	}
+	return nil
+}
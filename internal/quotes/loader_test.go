@@ -0,0 +1,93 @@
+package quotes
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/e6a5/zenta/internal/testutil"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "mine.txt", "# My favorites\n\n🌙 Rest is part of the work.\nShip it, then breathe. - Me\n")
+	testutil.WriteFile(t, dir, "stoic.yaml", `
+- text: Waste no more time arguing what a good man should be. Be one.
+  author: Marcus Aurelius
+  emoji: "🏛️"
+  tags: [action]
+`)
+	testutil.WriteFile(t, dir, "notes.md", "ignored")
+
+	qs := New()
+	builtinStoic := len(builtinCollections[1].Quotes)
+
+	if errs := qs.LoadDir(dir); len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if err := qs.UseCollection("mine"); err != nil {
+		t.Fatalf("Expected user collection: %v", err)
+	}
+	if qs.QuoteCount() != 2 {
+		t.Errorf("Expected 2 quotes in user collection, got %d", qs.QuoteCount())
+	}
+
+	if err := qs.UseCollection("stoic"); err != nil {
+		t.Fatal(err)
+	}
+	all := qs.GetAllQuotes()
+	if len(all) != builtinStoic+1 {
+		t.Errorf("Expected user file to extend the stoic collection, got %d quotes", len(all))
+	}
+	got := all[len(all)-1]
+	if got.Author != "Marcus Aurelius" || got.Emoji != "🏛️" || !got.HasTag("action") {
+		t.Errorf("Expected YAML fields to be loaded, got %+v", got)
+	}
+}
+
+func TestLoadDirMissing(t *testing.T) {
+	if errs := New().LoadDir(filepath.Join(t.TempDir(), "nope")); len(errs) != 0 {
+		t.Errorf("Expected missing directory to be ignored, got %v", errs)
+	}
+}
+
+func TestLoadDirReportsMalformedEntries(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "broken.txt", "A fine quote.\n🌸\n")
+	testutil.WriteFile(t, dir, "broken.yaml", `
+- text: Fine.
+- author: Nobody
+- text: Typo
+  autor: Someone
+- just a string
+`)
+
+	qs := New()
+	errs := qs.LoadDir(dir)
+
+	expected := []string{
+		"broken.txt:2: quote has no text",
+		"broken.yaml:3: quote has no text",
+		`broken.yaml:5: unknown field "autor"`,
+		"broken.yaml:6: expected an entry",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, want := range expected {
+		var loadErr *LoadError
+		if !errors.As(errs[i], &loadErr) {
+			t.Errorf("Expected a LoadError, got %T", errs[i])
+		}
+		if !strings.Contains(errs[i].Error(), want) {
+			t.Errorf("Expected error containing %q, got %q", want, errs[i])
+		}
+	}
+
+	// Valid entries are still loaded
+	if err := qs.UseCollection("broken"); err != nil || qs.QuoteCount() != 2 {
+		t.Errorf("Expected the 2 valid quotes to load, got %d (%v)", qs.QuoteCount(), err)
+	}
+}
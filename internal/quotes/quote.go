@@ -0,0 +1,104 @@
+package quotes
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultEmoji is shown beside quotes that don't carry their own emoji
+const DefaultEmoji = "💭"
+
+// Quote is a single mindfulness quote with its attribution and metadata
+type Quote struct {
+	Text     string
+	Author   string
+	Source   string   // Book or work the quote comes from, if known
+	Emoji    string   // Shown beside the first line; DefaultEmoji when empty
+	Tags     []string // Themes such as "presence" or "letting go"
+	Language string   // BCP 47 language tag, e.g. "en"
+}
+
+// String formats the quote in the legacy "emoji text - author" form
+func (q Quote) String() string {
+	text := q.Text
+	if q.Emoji != "" {
+		text = q.Emoji + " " + text
+	}
+	if q.Author != "" {
+		text += " - " + q.Author
+	}
+	return text
+}
+
+// Attribution returns the "Author, Source" line, or an empty string
+func (q Quote) Attribution() string {
+	switch {
+	case q.Author != "" && q.Source != "":
+		return q.Author + ", " + q.Source
+	case q.Author != "":
+		return q.Author
+	default:
+		return q.Source
+	}
+}
+
+// DisplayEmoji returns the emoji to show beside the quote
+func (q Quote) DisplayEmoji() string {
+	if q.Emoji == "" {
+		return DefaultEmoji
+	}
+	return q.Emoji
+}
+
+// HasTag reports whether the quote is tagged with tag (case-insensitive)
+func (q Quote) HasTag(tag string) bool {
+	for _, t := range q.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse reads a quote in the legacy "emoji text - author" form
+func Parse(s string) Quote {
+	var q Quote
+	text := strings.TrimSpace(s)
+
+	if first, rest, found := strings.Cut(text, " "); found && isEmoji(firstRune(first)) {
+		q.Emoji = first
+		text = strings.TrimSpace(rest)
+	} else if !found && isEmoji(firstRune(first)) {
+		q.Emoji = first
+		text = ""
+	}
+
+	if i := strings.LastIndex(text, " - "); i >= 0 {
+		q.Author = strings.TrimSpace(text[i+3:])
+		text = strings.TrimSpace(text[:i])
+	}
+
+	q.Text = text
+	return q
+}
+
+// firstRune decodes the first character of s
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// isEmoji checks if a rune is an emoji character
+func isEmoji(r rune) bool {
+	// Simple check for emoji ranges
+	return (r >= 0x1F600 && r <= 0x1F64F) || // Emoticons
+		(r >= 0x1F300 && r <= 0x1F5FF) || // Misc Symbols
+		(r >= 0x1F680 && r <= 0x1F6FF) || // Transport
+		(r >= 0x2600 && r <= 0x26FF) || // Misc symbols
+		(r >= 0x2700 && r <= 0x27BF) || // Dingbats
+		(r >= 0xFE00 && r <= 0xFE0F) || // Variation Selectors
+		(r >= 0x1F900 && r <= 0x1F9FF) || // Supplemental Symbols
+		(r >= 0x1FA70 && r <= 0x1FAFF) || // Symbols Extended-A
+		(r >= 0x2300 && r <= 0x23FF) || // Misc Technical (⏳)
+		(r >= 0x2B00 && r <= 0x2BFF) // Misc Symbols and Arrows (⭐)
+}
@@ -1,74 +1,161 @@
 // Package quotes provides mindfulness quotes and quote management functionality.
-// It offers a collection of built-in quotes inspired by Zen, Stoicism, and mindfulness practices.
+// It offers built-in collections inspired by Zen, Stoicism, Taoism, and mindfulness
+// practices, and can merge in quote files written by the user.
 package quotes
 
 import (
 	"crypto/rand"
+	"fmt"
 	"math/big"
+	"sort"
 )
 
-// Built-in mindfulness quotes inspired by Zen, Stoicism, and mindfulness practices
-var builtinQuotes = []string{
-	"🧘 Take a breath. This moment is all there is.",
-	"🌱 What you resist persists. What you accept transforms.",
-	"⭐ The present moment is the only time over which we have dominion. - Thich Nhat Hanh",
-	"🍃 Wherever you are, be there totally. - Eckhart Tolle",
-	"🌊 You have power over your mind—not outside events. Realize this, and you will find strength. - Marcus Aurelius",
-	"🎯 The best way to take care of the future is to take care of the present moment.",
-	"🌸 Peace comes from within. Do not seek it without. - Buddha",
-	"🕯️ Between stimulus and response there is a space. In that space is our power to choose our response.",
-	"🌿 Mindfulness is about being fully awake in our lives.",
-	"⚡ This too shall pass. Notice what arises, and let it go.",
-	"🎋 The mind is everything. What you think you become. - Buddha",
-	"🌅 Each morning we are born again. What we do today is what matters most.",
-	"🪨 Be like water making its way through cracks. - Bruce Lee",
-	"🌊 Flow with whatever may happen and let your mind be free.",
-	"⭐ The quieter you become, the more you are able to hear.",
-	"🌱 In the beginner's mind there are many possibilities, in the expert's mind there are few. - Shunryu Suzuki",
-	"🕊️ Let go or be dragged. - Zen Proverb",
-	"🌸 The only way out is through.",
-	"🎯 Focus on the step in front of you, not the whole staircase.",
-	"🌿 Breathe in calm, breathe out chaos.",
-	"⚖️ Balance is not something you find, it's something you create.",
-	"🌊 When you realize nothing is lacking, the whole world belongs to you. - Lao Tzu",
-	"🪷 Muddy water is best cleared by leaving it alone. - Alan Watts",
-	"🌅 Every moment is a fresh beginning. - T.S. Eliot",
-	"🎋 Simplicity is the ultimate sophistication.",
+// DefaultQuote is shown when no quotes are available
+var DefaultQuote = Quote{Emoji: "🧘", Text: "Take a breath. This moment is all there is.", Language: "en"}
+
+// Collection is a named, themed group of quotes
+type Collection struct {
+	Name   string
+	Quotes []Quote
 }
 
-// QuoteService handles quote retrieval and management
-type QuoteService struct{}
+// Built-in mindfulness quotes, grouped into themed collections
+var builtinCollections = []Collection{
+	{
+		Name: "zen",
+		Quotes: []Quote{
+			{Emoji: "🧘", Text: "Take a breath. This moment is all there is.", Tags: []string{"presence", "breath"}, Language: "en"},
+			{Emoji: "🌱", Text: "What you resist persists. What you accept transforms.", Tags: []string{"acceptance", "letting go"}, Language: "en"},
+			{Emoji: "🌸", Text: "Peace comes from within. Do not seek it without.", Author: "Buddha", Tags: []string{"calm"}, Language: "en"},
+			{Emoji: "🎋", Text: "The mind is everything. What you think you become.", Author: "Buddha", Tags: []string{"mind"}, Language: "en"},
+			{Emoji: "⭐", Text: "The quieter you become, the more you are able to hear.", Tags: []string{"calm", "stillness"}, Language: "en"},
+			{Emoji: "🌱", Text: "In the beginner's mind there are many possibilities, in the expert's mind there are few.", Author: "Shunryu Suzuki", Source: "Zen Mind, Beginner's Mind", Tags: []string{"beginning", "curiosity"}, Language: "en"},
+			{Emoji: "🕊️", Text: "Let go or be dragged.", Author: "Zen Proverb", Tags: []string{"letting go"}, Language: "en"},
+			{Emoji: "🪷", Text: "Muddy water is best cleared by leaving it alone.", Author: "Alan Watts", Tags: []string{"stillness", "letting go"}, Language: "en"},
+			{Emoji: "🍵", Text: "Before enlightenment, chop wood, carry water. After enlightenment, chop wood, carry water.", Author: "Zen Proverb", Tags: []string{"rhythm", "work"}, Language: "en"},
+		},
+	},
+	{
+		Name: "stoic",
+		Quotes: []Quote{
+			{Emoji: "🌊", Text: "You have power over your mind—not outside events. Realize this, and you will find strength.", Author: "Marcus Aurelius", Source: "Meditations", Tags: []string{"mind", "strength"}, Language: "en"},
+			{Emoji: "🏛️", Text: "We suffer more often in imagination than in reality.", Author: "Seneca", Source: "Letters from a Stoic", Tags: []string{"worry"}, Language: "en"},
+			{Emoji: "🌿", Text: "It is not things that disturb us, but our judgments about things.", Author: "Epictetus", Source: "Enchiridion", Tags: []string{"mind", "acceptance"}, Language: "en"},
+			{Emoji: "🪨", Text: "What stands in the way becomes the way.", Author: "Marcus Aurelius", Source: "Meditations", Tags: []string{"obstacles", "action"}, Language: "en"},
+			{Emoji: "⏳", Text: "Confine yourself to the present.", Author: "Marcus Aurelius", Source: "Meditations", Tags: []string{"presence"}, Language: "en"},
+		},
+	},
+	{
+		Name: "tao",
+		Quotes: []Quote{
+			{Emoji: "🪨", Text: "Be like water making its way through cracks.", Author: "Bruce Lee", Tags: []string{"flow"}, Language: "en"},
+			{Emoji: "🌊", Text: "Flow with whatever may happen and let your mind be free.", Tags: []string{"flow", "letting go"}, Language: "en"},
+			{Emoji: "🌊", Text: "When you realize nothing is lacking, the whole world belongs to you.", Author: "Lao Tzu", Tags: []string{"contentment"}, Language: "en"},
+			{Emoji: "🍃", Text: "Nature does not hurry, yet everything is accomplished.", Author: "Lao Tzu", Tags: []string{"patience", "rhythm"}, Language: "en"},
+			{Emoji: "🌊", Text: "Do you have the patience to wait until your mud settles and the water is clear?", Author: "Lao Tzu", Source: "Tao Te Ching", Tags: []string{"patience", "stillness"}, Language: "en"},
+		},
+	},
+	{
+		Name: "mindfulness",
+		Quotes: []Quote{
+			{Emoji: "⭐", Text: "The present moment is the only time over which we have dominion.", Author: "Thich Nhat Hanh", Tags: []string{"presence"}, Language: "en"},
+			{Emoji: "🍃", Text: "Wherever you are, be there totally.", Author: "Eckhart Tolle", Tags: []string{"presence"}, Language: "en"},
+			{Emoji: "🎯", Text: "The best way to take care of the future is to take care of the present moment.", Tags: []string{"presence", "focus"}, Language: "en"},
+			{Emoji: "🕯️", Text: "Between stimulus and response there is a space. In that space is our power to choose our response.", Tags: []string{"pause", "choice"}, Language: "en"},
+			{Emoji: "🌿", Text: "Mindfulness is about being fully awake in our lives.", Tags: []string{"awareness"}, Language: "en"},
+			{Emoji: "🌅", Text: "Each morning we are born again. What we do today is what matters most.", Tags: []string{"beginning"}, Language: "en"},
+			{Emoji: "🎯", Text: "Focus on the step in front of you, not the whole staircase.", Tags: []string{"focus"}, Language: "en"},
+			{Emoji: "🌿", Text: "Breathe in calm, breathe out chaos.", Tags: []string{"breath", "calm", "rhythm"}, Language: "en"},
+			{Emoji: "⚖️", Text: "Balance is not something you find, it's something you create.", Tags: []string{"balance"}, Language: "en"},
+			{Emoji: "🌅", Text: "Every moment is a fresh beginning.", Author: "T.S. Eliot", Tags: []string{"beginning"}, Language: "en"},
+			{Emoji: "🎋", Text: "Simplicity is the ultimate sophistication.", Tags: []string{"simplicity"}, Language: "en"},
+			{Emoji: "⚡", Text: "This too shall pass. Notice what arises, and let it go.", Tags: []string{"letting go", "impermanence"}, Language: "en"},
+			{Emoji: "🌸", Text: "The only way out is through.", Tags: []string{"action"}, Language: "en"},
+		},
+	},
+}
 
-// New creates a new QuoteService
+// QuoteService handles quote retrieval across built-in and user collections
+type QuoteService struct {
+	collections map[string][]Quote
+	active      string // Selected collection, or empty for all
+}
+
+// New creates a new QuoteService holding the built-in collections
 func New() *QuoteService {
-	return &QuoteService{}
+	qs := &QuoteService{collections: make(map[string][]Quote)}
+	for _, c := range builtinCollections {
+		qs.Add(c.Name, c.Quotes...)
+	}
+	return qs
+}
+
+// Add appends quotes to a collection, creating it if needed
+func (qs *QuoteService) Add(collection string, quotes ...Quote) {
+	qs.collections[collection] = append(qs.collections[collection], quotes...)
+}
+
+// Collections returns the names of all known collections, sorted
+func (qs *QuoteService) Collections() []string {
+	names := make([]string, 0, len(qs.collections))
+	for name := range qs.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-// GetRandomQuote returns a random quote from the built-in collection
-func (qs *QuoteService) GetRandomQuote() string {
-	if len(builtinQuotes) == 0 {
-		return "🧘 Take a breath. This moment is all there is."
+// UseCollection restricts selection to a single collection.
+// An empty name selects from every collection.
+func (qs *QuoteService) UseCollection(name string) error {
+	if name != "" {
+		if _, ok := qs.collections[name]; !ok {
+			return fmt.Errorf("unknown quote collection %q", name)
+		}
+	}
+	qs.active = name
+	return nil
+}
+
+// GetRandomQuote returns a random quote from the active collection
+func (qs *QuoteService) GetRandomQuote() Quote {
+	quotes := qs.activeQuotes()
+	if len(quotes) == 0 {
+		return DefaultQuote
 	}
 
 	// Use crypto/rand for better randomness
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(builtinQuotes))))
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(quotes))))
 	if err != nil {
 		// Fallback to first quote if crypto/rand fails
-		return builtinQuotes[0]
+		return quotes[0]
 	}
 
-	return builtinQuotes[n.Int64()]
+	return quotes[n.Int64()]
 }
 
-// GetAllQuotes returns all built-in quotes (useful for testing or exporting)
-func (qs *QuoteService) GetAllQuotes() []string {
-	// Return a copy to prevent external modification
-	quotes := make([]string, len(builtinQuotes))
-	copy(quotes, builtinQuotes)
-	return quotes
+// GetAllQuotes returns all quotes in the active collection (useful for testing or exporting)
+func (qs *QuoteService) GetAllQuotes() []Quote {
+	// activeQuotes always builds a fresh slice, so callers can't modify ours
+	return qs.activeQuotes()
 }
 
 // QuoteCount returns the number of available quotes
 func (qs *QuoteService) QuoteCount() int {
-	return len(builtinQuotes)
+	return len(qs.activeQuotes())
+}
+
+// activeQuotes returns a copy of the quotes available for selection
+func (qs *QuoteService) activeQuotes() []Quote {
+	if qs.active != "" {
+		quotes := make([]Quote, len(qs.collections[qs.active]))
+		copy(quotes, qs.collections[qs.active])
+		return quotes
+	}
+
+	var quotes []Quote
+	for _, name := range qs.Collections() {
+		quotes = append(quotes, qs.collections[name]...)
+	}
+	return quotes
 }
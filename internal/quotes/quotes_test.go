@@ -19,14 +19,14 @@ func TestGetRandomQuote(t *testing.T) {
 	quotes := make(map[string]bool)
 	for i := 0; i < 10; i++ {
 		quote := qs.GetRandomQuote()
-		if quote == "" {
+		if quote.Text == "" {
 			t.Error("Expected non-empty quote")
 		}
-		quotes[quote] = true
+		quotes[quote.Text] = true
 	}
 
-	// With 25 quotes, getting the same quote 10 times would be very unlikely
-	if len(quotes) == 1 && len(builtinQuotes) > 1 {
+	// With dozens of quotes, getting the same quote 10 times would be very unlikely
+	if len(quotes) == 1 && builtinCount() > 1 {
 		t.Error("Expected some variation in quotes, got same quote 10 times")
 	}
 }
@@ -35,13 +35,13 @@ func TestGetAllQuotes(t *testing.T) {
 	qs := New()
 	quotes := qs.GetAllQuotes()
 
-	if len(quotes) != len(builtinQuotes) {
-		t.Errorf("Expected %d quotes, got %d", len(builtinQuotes), len(quotes))
+	if len(quotes) != builtinCount() {
+		t.Errorf("Expected %d quotes, got %d", builtinCount(), len(quotes))
 	}
 
 	// Test that it returns a copy (modifying shouldn't affect original)
 	originalLen := len(quotes)
-	_ = append(quotes, "Test quote") // Use blank identifier to avoid ineffectual assignment
+	_ = append(quotes, Quote{Text: "Test quote"}) // Use blank identifier to avoid ineffectual assignment
 
 	quotesAgain := qs.GetAllQuotes()
 	if len(quotesAgain) != originalLen {
@@ -53,8 +53,8 @@ func TestQuoteCount(t *testing.T) {
 	qs := New()
 	count := qs.QuoteCount()
 
-	if count != len(builtinQuotes) {
-		t.Errorf("Expected count %d, got %d", len(builtinQuotes), count)
+	if count != builtinCount() {
+		t.Errorf("Expected count %d, got %d", builtinCount(), count)
 	}
 
 	if count == 0 {
@@ -64,36 +64,137 @@ func TestQuoteCount(t *testing.T) {
 
 func TestBuiltinQuotesContent(t *testing.T) {
 	// Test that all quotes are non-empty and meaningful
-	for i, quote := range builtinQuotes {
-		if quote == "" {
-			t.Errorf("Quote %d is empty", i)
+	for _, c := range builtinCollections {
+		if len(c.Quotes) == 0 {
+			t.Errorf("Collection %s is empty", c.Name)
 		}
 
-		if len(quote) < 10 {
-			t.Errorf("Quote %d seems too short: %s", i, quote)
-		}
+		for i, quote := range c.Quotes {
+			if quote.Text == "" {
+				t.Errorf("Quote %s/%d is empty", c.Name, i)
+			}
+
+			if len(quote.Text) < 10 {
+				t.Errorf("Quote %s/%d seems too short: %s", c.Name, i, quote)
+			}
+
+			// Verify it has some meaningful content
+			if len(strings.TrimSpace(quote.Text)) < 5 {
+				t.Errorf("Quote %s/%d appears to have no meaningful content: %s", c.Name, i, quote)
+			}
 
-		// Verify it has some meaningful content
-		if len(strings.TrimSpace(quote)) < 5 {
-			t.Errorf("Quote %d appears to have no meaningful content: %s", i, quote)
+			if strings.Contains(quote.Text, " - ") {
+				t.Errorf("Quote %s/%d embeds its attribution in the text: %s", c.Name, i, quote)
+			}
+
+			if quote.Emoji == "" || quote.Language == "" || len(quote.Tags) == 0 {
+				t.Errorf("Quote %s/%d is missing emoji, language or tags: %s", c.Name, i, quote)
+			}
 		}
 	}
 }
 
 func TestFallbackQuote(t *testing.T) {
 	// Test the fallback behavior when no quotes available
-	// We'll temporarily modify builtinQuotes to test this
-	originalQuotes := builtinQuotes
-	builtinQuotes = []string{} // Empty quotes
+	qs := &QuoteService{collections: map[string][]Quote{}}
+	quote := qs.GetRandomQuote()
 
+	if quote.Text != DefaultQuote.Text {
+		t.Errorf("Expected fallback quote %s, got %s", DefaultQuote, quote)
+	}
+}
+
+func TestUseCollection(t *testing.T) {
 	qs := New()
-	quote := qs.GetRandomQuote()
 
-	expected := "🧘 Take a breath. This moment is all there is."
-	if quote != expected {
-		t.Errorf("Expected fallback quote %s, got %s", expected, quote)
+	if err := qs.UseCollection("stoic"); err != nil {
+		t.Fatalf("Expected stoic collection to exist: %v", err)
+	}
+
+	stoic := qs.GetAllQuotes()
+	if len(stoic) == 0 || len(stoic) >= builtinCount() {
+		t.Errorf("Expected a subset of quotes, got %d of %d", len(stoic), builtinCount())
+	}
+
+	for i := 0; i < 10; i++ {
+		if quote := qs.GetRandomQuote(); !contains(stoic, quote.Text) {
+			t.Errorf("Expected a stoic quote, got %s", quote)
+		}
+	}
+
+	if err := qs.UseCollection("nonexistent"); err == nil {
+		t.Error("Expected error for unknown collection")
+	}
+
+	if err := qs.UseCollection(""); err != nil || qs.QuoteCount() != builtinCount() {
+		t.Error("Expected empty collection name to select all quotes")
+	}
+}
+
+// builtinCount returns the number of quotes across all built-in collections
+func builtinCount() int {
+	count := 0
+	for _, c := range builtinCollections {
+		count += len(c.Quotes)
+	}
+	return count
+}
+
+func contains(list []Quote, text string) bool {
+	for _, item := range list {
+		if item.Text == text {
+			return true
+		}
 	}
+	return false
+}
+
+func TestQuoteString(t *testing.T) {
+	q := Quote{Emoji: "🍃", Text: "Wherever you are, be there totally.", Author: "Eckhart Tolle"}
+	want := "🍃 Wherever you are, be there totally. - Eckhart Tolle"
+	if got := q.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if got := (Quote{Text: "Just breathe."}).String(); got != "Just breathe." {
+		t.Errorf("Expected bare text, got %q", got)
+	}
+}
 
-	// Restore original quotes
-	builtinQuotes = originalQuotes
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  Quote
+	}{
+		{"🌸 Peace comes from within. - Buddha", Quote{Emoji: "🌸", Text: "Peace comes from within.", Author: "Buddha"}},
+		{"⭐ The quieter you become.", Quote{Emoji: "⭐", Text: "The quieter you become."}},
+		{"Plain words - Someone", Quote{Text: "Plain words", Author: "Someone"}},
+		{"Ça va - Émile", Quote{Text: "Ça va", Author: "Émile"}},
+		{"🌸", Quote{Emoji: "🌸"}},
+	}
+
+	for _, tc := range testCases {
+		got := Parse(tc.input)
+		if got.Emoji != tc.want.Emoji || got.Text != tc.want.Text || got.Author != tc.want.Author {
+			t.Errorf("Parse(%q) = %+v, want %+v", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestAttribution(t *testing.T) {
+	testCases := []struct {
+		quote Quote
+		want  string
+	}{
+		{Quote{Author: "Seneca", Source: "Letters from a Stoic"}, "Seneca, Letters from a Stoic"},
+		{Quote{Author: "Seneca"}, "Seneca"},
+		{Quote{Source: "Tao Te Ching"}, "Tao Te Ching"},
+		{Quote{}, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.quote.Attribution(); got != tc.want {
+			t.Errorf("Expected %q, got %q", tc.want, got)
+		}
+	}
 }
@@ -0,0 +1,225 @@
+package quotes
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// FavoriteWeight is how many times more often a favorite quote appears
+const FavoriteWeight = 3
+
+// allCollections names the rotation used when no collection is selected
+const allCollections = "all"
+
+// State is the quote selection state persisted between runs
+type State struct {
+	Rotations map[string]*Rotation `json:"rotations,omitempty"` // Keyed by collection
+	Favorites []string             `json:"favorites,omitempty"` // Quote keys
+}
+
+// Rotation is a persisted shuffle of one collection. Quotes are walked in
+// deck order, and the deck is reshuffled only once it is exhausted or the
+// collection changes.
+type Rotation struct {
+	Deck      []string `json:"deck"`
+	Position  int      `json:"position"`
+	Signature string   `json:"signature"` // Identifies the quotes and favorites the deck was built from
+}
+
+// Key returns a stable identifier for a quote, derived from its text
+func (q Quote) Key() string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(normalize(q.Text)))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// normalize lowercases text and collapses whitespace for comparisons
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// LoadState reads quote state from path. A missing file yields empty state.
+func LoadState(path string) (*State, error) {
+	state := &State{}
+	if _, err := storage.ReadJSON(path, state); err != nil {
+		return &State{}, err
+	}
+	return state, nil
+}
+
+// Save writes the state to path
+func (s *State) Save(path string) error {
+	return storage.WriteJSON(path, s)
+}
+
+// IsFavorite reports whether the quote is one of the user's favorites
+func (s *State) IsFavorite(q Quote) bool {
+	key := q.Key()
+	for _, fav := range s.Favorites {
+		if fav == key {
+			return true
+		}
+	}
+	return false
+}
+
+// weight returns how many times a quote appears in a deck
+func (s *State) weight(q Quote) int {
+	if s.IsFavorite(q) {
+		return FavoriteWeight
+	}
+	return 1
+}
+
+// NextInRotation returns the next quote in the active collection's
+// persisted shuffle, advancing the state. Among the quotes not yet shown
+// in this pass, the pick is weighted by the context rules, so fitting
+// quotes come sooner without ever repeating early. Callers save the state
+// afterwards.
+func (qs *QuoteService) NextInRotation(state *State, rng *rand.Rand, ctx Context, rules []Rule) Quote {
+	quotes := qs.activeQuotes()
+	if len(quotes) == 0 {
+		return DefaultQuote
+	}
+
+	byKey := make(map[string]Quote, len(quotes))
+	for _, q := range quotes {
+		byKey[q.Key()] = q
+	}
+
+	if state.Rotations == nil {
+		state.Rotations = make(map[string]*Rotation)
+	}
+	name := qs.active
+	if name == "" {
+		name = allCollections
+	}
+	rot := state.Rotations[name]
+	if rot == nil {
+		rot = &Rotation{}
+		state.Rotations[name] = rot
+	}
+
+	signature := deckSignature(byKey, state)
+	if rot.Signature != signature || rot.Position >= len(rot.Deck) {
+		last := ""
+		if rot.Position > 0 && rot.Position <= len(rot.Deck) {
+			last = rot.Deck[rot.Position-1]
+		}
+		rot.Deck = shuffleDeck(quotes, state, rng, last)
+		rot.Position = 0
+		rot.Signature = signature
+	}
+
+	// Bring a well-fitting remaining quote forward, never the one just shown
+	previous := ""
+	if rot.Position > 0 {
+		previous = rot.Deck[rot.Position-1]
+	}
+	var candidates []Quote
+	var positions []int
+	for i := rot.Position; i < len(rot.Deck); i++ {
+		if rot.Deck[i] != previous {
+			candidates = append(candidates, byKey[rot.Deck[i]])
+			positions = append(positions, i)
+		}
+	}
+	if len(candidates) > 0 {
+		pick := positions[SelectForContext(candidates, ctx, rules, rng)]
+		rot.Deck[rot.Position], rot.Deck[pick] = rot.Deck[pick], rot.Deck[rot.Position]
+	}
+
+	key := rot.Deck[rot.Position]
+	rot.Position++
+	return byKey[key]
+}
+
+// QuoteOfTheDay deterministically picks a quote for the given date, so
+// every run on the same day shows the same quote. Favorites are weighted.
+func (qs *QuoteService) QuoteOfTheDay(day time.Time, state *State) Quote {
+	quotes := qs.activeQuotes()
+	if len(quotes) == 0 {
+		return DefaultQuote
+	}
+
+	// Order by key so the choice doesn't depend on file load order
+	sort.SliceStable(quotes, func(i, j int) bool { return quotes[i].Key() < quotes[j].Key() })
+
+	total := 0
+	for _, q := range quotes {
+		total += state.weight(q)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(day.Format("2006-01-02")))
+	pick := int(h.Sum64() % uint64(total))
+
+	for _, q := range quotes {
+		pick -= state.weight(q)
+		if pick < 0 {
+			return q
+		}
+	}
+	return quotes[len(quotes)-1]
+}
+
+// deckSignature fingerprints the quotes and favorites a deck is built from
+func deckSignature(byKey map[string]Quote, state *State) string {
+	keys := make([]string, 0, len(byKey))
+	for key, q := range byKey {
+		if state.IsFavorite(q) {
+			key += "*"
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(keys, ",")))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// shuffleDeck builds a weighted, shuffled deck of quote keys. Repeated
+// favorites are kept apart, and the deck never opens with the quote that
+// closed the previous one.
+func shuffleDeck(quotes []Quote, state *State, rng *rand.Rand, last string) []string {
+	var deck []string
+	seen := make(map[string]bool)
+	for _, q := range quotes {
+		key := q.Key()
+		if seen[key] {
+			continue // Duplicate text across collections
+		}
+		seen[key] = true
+		for i := 0; i < state.weight(q); i++ {
+			deck = append(deck, key)
+		}
+	}
+
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+
+	for i := range deck {
+		previous := last
+		if i > 0 {
+			previous = deck[i-1]
+		}
+		if deck[i] != previous {
+			continue
+		}
+		// Swap in the next different quote, if any
+		for j := i + 1; j < len(deck); j++ {
+			if deck[j] != previous {
+				deck[i], deck[j] = deck[j], deck[i]
+				break
+			}
+		}
+	}
+
+	return deck
+}
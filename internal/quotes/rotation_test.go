@@ -0,0 +1,118 @@
+package quotes
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testService(texts ...string) *QuoteService {
+	qs := &QuoteService{collections: map[string][]Quote{}}
+	for _, text := range texts {
+		qs.Add("test", Quote{Text: text})
+	}
+	return qs
+}
+
+func TestNextInRotationCoversEveryQuoteBeforeRepeating(t *testing.T) {
+	qs := testService("one", "two", "three", "four", "five")
+	state := &State{}
+	rng := rand.New(rand.NewSource(1))
+
+	var previous string
+	for round := 0; round < 3; round++ {
+		seen := make(map[string]bool)
+		for i := 0; i < qs.QuoteCount(); i++ {
+			q := qs.NextInRotation(state, rng, Context{}, nil)
+			if seen[q.Text] {
+				t.Fatalf("Round %d: %q repeated before the deck was exhausted", round, q.Text)
+			}
+			if q.Text == previous {
+				t.Fatalf("Round %d: %q shown twice in a row", round, q.Text)
+			}
+			seen[q.Text] = true
+			previous = q.Text
+		}
+	}
+}
+
+func TestNextInRotationReshufflesWhenCollectionChanges(t *testing.T) {
+	qs := testService("one", "two")
+	state := &State{}
+	rng := rand.New(rand.NewSource(1))
+
+	qs.NextInRotation(state, rng, Context{}, nil)
+	qs.Add("test", Quote{Text: "three"})
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		seen[qs.NextInRotation(state, rng, Context{}, nil).Text] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected the new quote to join a fresh deck, saw %v", seen)
+	}
+}
+
+func TestFavoritesAppearMoreOften(t *testing.T) {
+	qs := testService("one", "two", "three", "four")
+	fav := Quote{Text: "two"}
+	state := &State{Favorites: []string{fav.Key()}}
+	rng := rand.New(rand.NewSource(7))
+
+	counts := make(map[string]int)
+	deckSize := qs.QuoteCount() - 1 + FavoriteWeight
+	for i := 0; i < deckSize*10; i++ {
+		counts[qs.NextInRotation(state, rng, Context{}, nil).Text]++
+	}
+
+	if counts["two"] != FavoriteWeight*10 || counts["one"] != 10 {
+		t.Errorf("Expected favorite to appear %dx as often, got %v", FavoriteWeight, counts)
+	}
+}
+
+func TestQuoteOfTheDay(t *testing.T) {
+	qs := New()
+	state := &State{}
+	day := time.Date(2025, 7, 15, 8, 0, 0, 0, time.UTC)
+
+	first := qs.QuoteOfTheDay(day, state)
+	if again := qs.QuoteOfTheDay(day.Add(10*time.Hour), state); again.Text != first.Text {
+		t.Errorf("Expected the same quote all day, got %q and %q", first.Text, again.Text)
+	}
+
+	different := false
+	for i := 1; i <= 7; i++ {
+		if qs.QuoteOfTheDay(day.AddDate(0, 0, i), state).Text != first.Text {
+			different = true
+		}
+	}
+	if !different {
+		t.Error("Expected the quote to change over a week")
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotes.json")
+	qs := testService("one", "two", "three")
+	rng := rand.New(rand.NewSource(3))
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	first := qs.NextInRotation(state, rng, Context{}, nil)
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if qs.NextInRotation(restored, rng, Context{}, nil).Text == first.Text {
+			t.Errorf("Expected persisted rotation to continue past %q", first.Text)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package quotes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserCollection is the collection that 'zenta quote add' writes to
+const UserCollection = "mine"
+
+// Search returns quotes in the active collection whose text, author,
+// source or tags contain the query, ignoring case and spacing.
+func (qs *QuoteService) Search(query string) []Quote {
+	query = normalize(query)
+
+	var matches []Quote
+	for _, q := range qs.GetAllQuotes() {
+		haystack := normalize(strings.Join([]string{q.Text, q.Author, q.Source, strings.Join(q.Tags, " ")}, " "))
+		if strings.Contains(haystack, query) {
+			matches = append(matches, q)
+		}
+	}
+	return matches
+}
+
+// Find resolves a quote by its key, or by a search that matches exactly
+// one quote. Ambiguous searches return the candidates with an error.
+func (qs *QuoteService) Find(keyOrText string) (Quote, []Quote, error) {
+	for _, q := range qs.GetAllQuotes() {
+		if q.Key() == keyOrText {
+			return q, nil, nil
+		}
+	}
+
+	matches := qs.Search(keyOrText)
+	switch len(matches) {
+	case 0:
+		return Quote{}, nil, fmt.Errorf("no quote matches %q", keyOrText)
+	case 1:
+		return matches[0], nil, nil
+	default:
+		return Quote{}, matches, fmt.Errorf("%d quotes match %q; use an id", len(matches), keyOrText)
+	}
+}
+
+// Origin returns the collection holding a quote, and whether the quote is
+// one of the built-in ones rather than from the user's files
+func (qs *QuoteService) Origin(q Quote) (string, bool) {
+	key := q.Key()
+	for _, c := range builtinCollections {
+		for _, builtin := range c.Quotes {
+			if builtin.Key() == key {
+				return c.Name, true
+			}
+		}
+	}
+
+	for _, name := range qs.Collections() {
+		for _, other := range qs.collections[name] {
+			if other.Key() == key {
+				return name, false
+			}
+		}
+	}
+	return "", false
+}
+
+// SetFavorite marks or unmarks a quote as a favorite. It reports whether
+// the state changed.
+func (s *State) SetFavorite(q Quote, favorite bool) bool {
+	if s.IsFavorite(q) == favorite {
+		return false
+	}
+
+	key := q.Key()
+	if favorite {
+		s.Favorites = append(s.Favorites, key)
+		return true
+	}
+
+	kept := s.Favorites[:0]
+	for _, fav := range s.Favorites {
+		if fav != key {
+			kept = append(kept, fav)
+		}
+	}
+	s.Favorites = kept
+	return true
+}
+
+// AppendQuote adds a quote to the YAML quote file at path, creating it if
+// needed. Existing content and comments are left untouched.
+func AppendQuote(path string, q Quote) error {
+	return AppendQuotes(path, []Quote{q})
+}
+
+// AppendQuotes adds several quotes to the YAML quote file at path
+func AppendQuotes(path string, quotes []Quote) error {
+	entries := make([]yamlQuote, 0, len(quotes))
+	for _, q := range quotes {
+		if strings.TrimSpace(q.Text) == "" {
+			return fmt.Errorf("quote has no text")
+		}
+		entries = append(entries, yamlQuote{
+			Text:     q.Text,
+			Author:   q.Author,
+			Source:   q.Source,
+			Emoji:    q.Emoji,
+			Tags:     q.Tags,
+			Language: q.Language,
+		})
+	}
+
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	data = unescapeAstral(data)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// #nosec G304 -- writing the user's own quote file
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// unescapeAstral turns the \UXXXXXXXX escapes that the YAML encoder uses
+// for characters outside the Basic Multilingual Plane, such as most emoji,
+// back into the characters themselves so the file stays readable.
+func unescapeAstral(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 >= len(data) {
+			out = append(out, data[i])
+			continue
+		}
+
+		if data[i+1] == 'U' && i+10 <= len(data) {
+			if r, err := strconv.ParseUint(string(data[i+2:i+10]), 16, 32); err == nil && utf8.ValidRune(rune(r)) {
+				out = utf8.AppendRune(out, rune(r))
+				i += 9
+				continue
+			}
+		}
+
+		// Copy any other escape pair untouched
+		out = append(out, data[i], data[i+1])
+		i++
+	}
+	return out
+}
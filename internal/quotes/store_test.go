@@ -0,0 +1,132 @@
+package quotes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	qs := New()
+
+	testCases := []struct {
+		query   string
+		minimum int
+	}{
+		{"marcus aurelius", 3},
+		{"LETTING   go", 2},
+		{"tao te ching", 1},
+		{"no such words anywhere", 0},
+	}
+
+	for _, tc := range testCases {
+		matches := qs.Search(tc.query)
+		if len(matches) < tc.minimum || (tc.minimum == 0 && len(matches) != 0) {
+			t.Errorf("Search(%q) returned %d matches, expected at least %d", tc.query, len(matches), tc.minimum)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	qs := New()
+	target := qs.Search("chop wood")[0]
+
+	if q, _, err := qs.Find(target.Key()); err != nil || q.Text != target.Text {
+		t.Errorf("Expected to find quote by key, got %+v (%v)", q, err)
+	}
+	if q, _, err := qs.Find("chop wood"); err != nil || q.Text != target.Text {
+		t.Errorf("Expected to find quote by unique text, got %+v (%v)", q, err)
+	}
+	if _, candidates, err := qs.Find("Lao Tzu"); err == nil || len(candidates) < 2 {
+		t.Errorf("Expected ambiguous match to fail with candidates, got %d (%v)", len(candidates), err)
+	}
+	if _, _, err := qs.Find("xyzzy"); err == nil {
+		t.Error("Expected error when nothing matches")
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	qs := New()
+	mine := Quote{Text: "Ship it gently."}
+	qs.Add(UserCollection, mine)
+
+	if name, builtin := qs.Origin(qs.Search("chop wood")[0]); name == "" || !builtin {
+		t.Errorf("Expected a built-in collection, got %q (built-in %v)", name, builtin)
+	}
+	if name, builtin := qs.Origin(mine); name != UserCollection || builtin {
+		t.Errorf("Expected %q from the user's files, got %q (built-in %v)", UserCollection, name, builtin)
+	}
+}
+
+func TestSetFavorite(t *testing.T) {
+	state := &State{}
+	q := Quote{Text: "Let go or be dragged."}
+
+	if !state.SetFavorite(q, true) || !state.IsFavorite(q) {
+		t.Fatal("Expected quote to become a favorite")
+	}
+	if state.SetFavorite(q, true) {
+		t.Error("Expected favoriting twice to be a no-op")
+	}
+	if !state.SetFavorite(q, false) || state.IsFavorite(q) {
+		t.Error("Expected quote to be unfavorited")
+	}
+	if len(state.Favorites) != 0 {
+		t.Errorf("Expected no favorites left, got %v", state.Favorites)
+	}
+}
+
+func TestAppendQuote(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, UserCollection+".yaml")
+
+	if err := os.WriteFile(path, []byte("# My own quotes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	quotes := []Quote{
+		{Text: "Slow is smooth, smooth is fast.", Author: "Navy SEALs", Tags: []string{"focus"}},
+		{Text: "Rest is part of the work.", Emoji: "🌙"},
+	}
+	for _, q := range quotes {
+		if err := AppendQuote(path, q); err != nil {
+			t.Fatalf("AppendQuote failed: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# My own quotes\n") {
+		t.Error("Expected existing content to be preserved")
+	}
+	if !strings.Contains(string(data), "🌙") {
+		t.Errorf("Expected emoji to be written as-is, got:\n%s", data)
+	}
+
+	qs := &QuoteService{collections: map[string][]Quote{}}
+	if errs := qs.LoadDir(dir); len(errs) != 0 {
+		t.Fatalf("Appended file should load cleanly: %v\n%s", errs, data)
+	}
+	loaded := qs.GetAllQuotes()
+	if len(loaded) != 2 || loaded[0].Author != "Navy SEALs" || !loaded[0].HasTag("focus") || loaded[1].Emoji != "🌙" {
+		t.Errorf("Unexpected quotes after append: %+v", loaded)
+	}
+
+	if err := AppendQuote(path, Quote{Text: "  "}); err == nil {
+		t.Error("Expected error for a quote without text")
+	}
+}
+
+func TestUnescapeAstral(t *testing.T) {
+	testCases := []struct{ input, want string }{
+		{`emoji: "\U0001F319"`, `emoji: "🌙"`},
+		{`text: "a\\U0001F319"`, `text: "a\\U0001F319"`}, // An escaped backslash stays literal
+		{`text: "tab\there"`, `text: "tab\there"`},
+	}
+
+	for _, tc := range testCases {
+		if got := string(unescapeAstral([]byte(tc.input))); got != tc.want {
+			t.Errorf("unescapeAstral(%s) = %s, want %s", tc.input, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package quotes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/e6a5/zenta/internal/textwidth"
+)
+
+// Style controls how each line of a quote appears
+type Style string
+
+// Available quote display styles
+const (
+	StyleTypewriter Style = "typewriter" // Typed out one character at a time
+	StyleFade       Style = "fade"       // Each line fades in, dim first
+	StyleInstant    Style = "instant"    // Printed at once
+)
+
+// DefaultSpeed is the typewriter delay per character
+const DefaultSpeed = 50 * time.Millisecond
+
+// ANSI sequences for the fade style
+const (
+	dim   = "\033[2m"
+	reset = "\033[0m"
+)
+
+// DisplayOptions configures quote display
+type DisplayOptions struct {
+	Style Style
+	Speed time.Duration // Delay per character; zero shows the quote instantly
+}
+
+// DefaultDisplayOptions returns the classic, contemplative typewriter effect
+func DefaultDisplayOptions() DisplayOptions {
+	return DisplayOptions{Style: StyleTypewriter, Speed: DefaultSpeed}
+}
+
+// ParseStyle validates a style name
+func ParseStyle(name string) (Style, error) {
+	style := Style(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := lineStyles[style]; !ok {
+		return "", fmt.Errorf("unknown quote style %q (choose typewriter, fade or instant)", name)
+	}
+	return style, nil
+}
+
+// lineStyle writes one line of a quote after its prefix, pacing itself
+// with p. Styles must finish the line immediately once p is skipped.
+type lineStyle func(w io.Writer, prefix, line string, speed time.Duration, p *pacer)
+
+var lineStyles = map[Style]lineStyle{
+	StyleTypewriter: typewriterLine,
+	StyleFade:       fadeLine,
+	StyleInstant:    instantLine,
+}
+
+// typewriterLine types the line one grapheme at a time. Whole graphemes
+// keep accents and ZWJ emoji from appearing in pieces.
+func typewriterLine(w io.Writer, prefix, line string, speed time.Duration, p *pacer) {
+	fmt.Fprint(w, prefix)
+	for _, cluster := range textwidth.Graphemes(line) {
+		fmt.Fprint(w, cluster)
+		if unicode.IsSpace([]rune(cluster)[0]) {
+			p.wait(speed * 2 / 5) // Brief pause for spaces
+		} else {
+			p.wait(speed) // Slower, more contemplative typing
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// fadeLine shows the line dimmed, then brings it to full brightness
+func fadeLine(w io.Writer, prefix, line string, speed time.Duration, p *pacer) {
+	if !p.skipped {
+		fmt.Fprint(w, prefix+dim+line+reset)
+		p.wait(speed * 10)
+		fmt.Fprint(w, "\r")
+	}
+	fmt.Fprintln(w, prefix+line)
+	p.wait(speed * 6)
+}
+
+// instantLine prints the whole line at once
+func instantLine(w io.Writer, prefix, line string, _ time.Duration, _ *pacer) {
+	fmt.Fprintln(w, prefix+line)
+}
+
+// pacer sleeps between animation steps, until a key press asks to skip
+// the rest of the animation
+type pacer struct {
+	skip    <-chan byte
+	skipped bool
+}
+
+// wait pauses for d, returning early and for good once a key is pressed
+func (p *pacer) wait(d time.Duration) {
+	if p.skipped || d <= 0 {
+		return
+	}
+	if p.skip == nil {
+		time.Sleep(d)
+		return
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case _, ok := <-p.skip:
+		if ok {
+			p.skipped = true
+			return
+		}
+		p.skip = nil // Input closed; keep pacing without it
+		<-timer.C
+	case <-timer.C:
+	}
+}
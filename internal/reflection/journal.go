@@ -0,0 +1,98 @@
+package reflection
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/e6a5/zenta/internal/storage"
+)
+
+// DateLayout names journal files and is the date format for --review
+const DateLayout = "2006-01-02"
+
+// Answer is a written response to a reflection prompt
+type Answer struct {
+	Prompt string
+	Text   string
+}
+
+// JournalPath returns the Markdown file holding reflections written on day
+func JournalPath(dir string, day time.Time) string {
+	return filepath.Join(dir, day.Format(DateLayout)+".md")
+}
+
+// FormatEntry renders a reflection's answers as a Markdown section.
+// Unanswered prompts are left out.
+func FormatEntry(when time.Time, title string, answers []Answer) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s · %s\n\n", when.Format("15:04"), strings.TrimSpace(title))
+	for _, a := range answers {
+		text := strings.TrimSpace(a.Text)
+		if text == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "**%s**\n\n%s\n\n", a.Prompt, text)
+	}
+	return b.String()
+}
+
+// SaveEntry appends a reflection's answers to the day's journal, returning
+// the file written. Nothing is written when every answer is empty.
+func SaveEntry(dir string, when time.Time, title string, answers []Answer) (string, error) {
+	answered := false
+	for _, a := range answers {
+		if strings.TrimSpace(a.Text) != "" {
+			answered = true
+		}
+	}
+	if !answered {
+		return "", nil
+	}
+
+	path := JournalPath(dir, when)
+	existing, found, err := ReadJournal(dir, when)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		existing = []byte(fmt.Sprintf("# Reflections · %s\n\n", when.Format(DateLayout)))
+	}
+
+	entry := FormatEntry(when, title, answers)
+	return path, storage.WriteData(path, append(existing, entry...))
+}
+
+// ReadJournal returns the reflections written on day, decrypting them
+// when they're in the vault. It returns false without an error when
+// nothing was written that day.
+func ReadJournal(dir string, day time.Time) ([]byte, bool, error) {
+	return storage.ReadData(JournalPath(dir, day))
+}
+
+// JournalDays lists the days with written reflections, oldest first
+func JournalDays(dir string) ([]time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var days []time.Time
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		day, err := time.ParseInLocation(DateLayout, name, time.Local)
+		if entry.IsDir() || name == entry.Name() || err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, nil
+}
@@ -0,0 +1,75 @@
+package reflection
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveEntryAppendsToTheDay(t *testing.T) {
+	dir := t.TempDir()
+	morning := time.Date(2026, 10, 18, 8, 30, 0, 0, time.Local)
+	evening := time.Date(2026, 10, 18, 21, 5, 0, 0, time.Local)
+
+	path, err := SaveEntry(dir, morning, "🌅  Morning Intention", []Answer{
+		{Prompt: "What matters most today?", Text: "Shipping the fix calmly."},
+		{Prompt: "What can you let be imperfect?", Text: "  "},
+	})
+	if err != nil {
+		t.Fatalf("SaveEntry failed: %v", err)
+	}
+	if _, err := SaveEntry(dir, evening, "Evening", []Answer{{Prompt: "What pulled you away?", Text: "Slack."}}); err != nil {
+		t.Fatalf("SaveEntry failed: %v", err)
+	}
+
+	data, found, err := ReadJournal(dir, morning)
+	if err != nil || !found {
+		t.Fatalf("Expected the journal to be found: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{"# Reflections · 2026-10-18", "## 08:30 · 🌅  Morning Intention", "Shipping the fix calmly.", "## 21:05 · Evening", "Slack."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in journal:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "imperfect") {
+		t.Error("Expected unanswered prompts to be left out")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected a private file, got %v", info.Mode().Perm())
+	}
+}
+
+func TestSaveEntryWithoutAnswers(t *testing.T) {
+	dir := t.TempDir()
+	path, err := SaveEntry(dir, time.Now(), "Evening", []Answer{{Prompt: "Why?", Text: ""}})
+	if err != nil || path != "" {
+		t.Errorf("Expected nothing to be written, got %q (%v)", path, err)
+	}
+	if days, _ := JournalDays(dir); len(days) != 0 {
+		t.Errorf("Expected no journal days, got %v", days)
+	}
+}
+
+func TestJournalDays(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"2026-10-18.md", "2026-09-01.md", "notes.txt", "2026-13-40.md"} {
+		if err := os.WriteFile(dir+"/"+name, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	days, err := JournalDays(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 || days[0].Format(DateLayout) != "2026-09-01" || days[1].Format(DateLayout) != "2026-10-18" {
+		t.Errorf("Unexpected days %v", days)
+	}
+}
@@ -0,0 +1,113 @@
+package reflection
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/e6a5/zenta/internal/named"
+)
+
+// yamlSet is the file format of a prompt set
+type yamlSet struct {
+	Title        string     `yaml:"title"`
+	Instructions []yamlLine `yaml:"instructions"`
+	PromptTitle  string     `yaml:"prompt_title"`
+	Prompts      []yamlLine `yaml:"prompts"`
+	Closing      []yamlLine `yaml:"closing"`
+}
+
+// yamlLine is a line given either as plain text or as text with a pause
+type yamlLine struct {
+	Text  string         `yaml:"text"`
+	Pause *time.Duration `yaml:"pause"`
+}
+
+// UnmarshalYAML accepts a plain string as a line with the default pause
+func (l *yamlLine) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		l.Text = value.Value
+		return nil
+	}
+
+	type plain yamlLine
+	var p plain
+	if err := value.Decode(&p); err != nil {
+		return err
+	}
+	*l = yamlLine(p)
+	return nil
+}
+
+// toLines converts file lines, applying the default pause where none is set
+func toLines(raw []yamlLine, pause time.Duration) []Line {
+	result := make([]Line, len(raw))
+	for i, l := range raw {
+		result[i] = Line{Text: strings.TrimSpace(l.Text), Pause: pause}
+		if l.Pause != nil {
+			result[i].Pause = *l.Pause
+		}
+	}
+	return result
+}
+
+// LoadFile reads and validates a prompt set. It is named after the file.
+func LoadFile(path string) (PromptSet, error) {
+	// #nosec G304 -- reading the user's own prompt set
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return PromptSet{}, err
+	}
+
+	var raw yamlSet
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		return PromptSet{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	set := PromptSet{
+		Name:         strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Title:        strings.TrimSpace(raw.Title),
+		Instructions: toLines(raw.Instructions, InstructionPause),
+		PromptTitle:  strings.TrimSpace(raw.PromptTitle),
+		Prompts:      toLines(raw.Prompts, PromptPause),
+		Closing:      toLines(raw.Closing, ClosingPause),
+		Source:       path,
+	}
+	if err := set.Validate(); err != nil {
+		return PromptSet{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return set, nil
+}
+
+// setFiles finds prompt sets by name
+var setFiles = named.Files[PromptSet]{
+	Kind:     "prompt set",
+	Builtin:  builtinSets,
+	Name:     func(x PromptSet) string { return x.Name },
+	LoadFile: LoadFile,
+}
+
+// LoadDir reads every prompt set (*.yaml, *.yml) in dir. A missing directory
+// is not an error; invalid files are skipped and reported.
+func LoadDir(dir string) ([]PromptSet, []error) {
+	return setFiles.LoadDir(dir)
+}
+
+// Available returns the built-in prompt sets together with those in dir,
+// sorted by name. A file named after a built-in set replaces it.
+func Available(dir string) ([]PromptSet, []error) {
+	return setFiles.Available(dir)
+}
+
+// Find returns the prompt set called name, looking in dir and then the
+// built-in prompt sets. A name that is a path to a file loads that file.
+func Find(name, dir string) (PromptSet, error) {
+	return setFiles.Find(name, dir)
+}
@@ -1,32 +1,168 @@
 // Package reflection provides prompts for mindful reflection sessions.
 package reflection
 
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultSet is the prompt set used when none is chosen
+const DefaultSet = "evening"
+
+// Default pauses after each kind of line, used when a line sets none
+const (
+	TitlePause       = 1 * time.Second
+	InstructionPause = 3 * time.Second
+	PromptTitlePause = 2 * time.Second
+	PromptPause      = 8 * time.Second
+	ClosingPause     = 3 * time.Second
+)
+
+// MaxPause is the longest pause a single line may ask for
+const MaxPause = 10 * time.Minute
+
+// Line is a line of reflection text and the pause that follows it
+type Line struct {
+	Text  string
+	Pause time.Duration
+}
+
 // PromptSet contains the text for a reflection session.
 type PromptSet struct {
+	Name         string
 	Title        string
-	Instructions []string
+	Instructions []Line
 	PromptTitle  string
-	Prompts      []string
-	Closing      []string
+	Prompts      []Line
+	Closing      []Line
+	Source       string // File the set was loaded from; empty for built-in sets
 }
 
 // GetDefaultPrompts returns the default set of reflection prompts.
 func GetDefaultPrompts() PromptSet {
-	return PromptSet{
+	return builtinSets[0]
+}
+
+// BuiltinSets returns the prompt sets that ship with zenta
+func BuiltinSets() []PromptSet {
+	return append([]PromptSet(nil), builtinSets...)
+}
+
+// Validate checks a prompt set for missing text and unreasonable pauses
+func (ps PromptSet) Validate() error {
+	if ps.Title == "" {
+		return fmt.Errorf("missing title")
+	}
+	if len(ps.Prompts) == 0 {
+		return fmt.Errorf("no prompts")
+	}
+
+	sections := []struct {
+		name  string
+		lines []Line
+	}{
+		{"instruction", ps.Instructions},
+		{"prompt", ps.Prompts},
+		{"closing line", ps.Closing},
+	}
+	for _, section := range sections {
+		for i, line := range section.lines {
+			if line.Text == "" {
+				return fmt.Errorf("%s %d has no text", section.name, i+1)
+			}
+			if line.Pause < 0 || line.Pause > MaxPause {
+				return fmt.Errorf("%s %d: pause must be between 0 and %v", section.name, i+1, MaxPause)
+			}
+		}
+	}
+	return nil
+}
+
+// lines builds lines that share a pause
+func lines(pause time.Duration, texts ...string) []Line {
+	result := make([]Line, len(texts))
+	for i, text := range texts {
+		result[i] = Line{Text: text, Pause: pause}
+	}
+	return result
+}
+
+// builtinSets are the prompt sets compiled into the binary. The first is
+// the default.
+var builtinSets = []PromptSet{
+	{
+		Name:  "evening",
 		Title: "🕯️  Evening Reflection",
-		Instructions: []string{
-			"   Close your eyes for a moment...",
-			"   Take three deep breaths...",
+		Instructions: []Line{
+			{Text: "Close your eyes for a moment...", Pause: InstructionPause},
+			// Give more time for taking breaths
+			{Text: "Take three deep breaths...", Pause: 5 * time.Second},
 		},
-		PromptTitle: "   📝 Gentle reflection:",
-		Prompts: []string{
-			"      • What thoughts kept pulling you away today?",
-			"      • Were there moments when you were truly present?",
-			"      • What patterns do you notice in your mind?",
+		PromptTitle: "📝 Gentle reflection:",
+		Prompts: lines(PromptPause,
+			"What thoughts kept pulling you away today?",
+			"Were there moments when you were truly present?",
+			"What patterns do you notice in your mind?",
+		),
+		Closing: lines(ClosingPause,
+			"These are just thoughts. They come and go like clouds.",
+			"The noticing itself is the practice. 🙏",
+		),
+	},
+	{
+		Name:  "morning",
+		Title: "🌅  Morning Intention",
+		Instructions: []Line{
+			{Text: "Sit comfortably and let your shoulders drop...", Pause: InstructionPause},
+			{Text: "Take three slow breaths...", Pause: 5 * time.Second},
 		},
-		Closing: []string{
-			"   These are just thoughts. They come and go like clouds.",
-			"   The noticing itself is the practice. 🙏",
+		PromptTitle: "📝 Setting an intention:",
+		Prompts: lines(PromptPause,
+			"What matters most today?",
+			"How do you want to meet difficulty when it comes?",
+			"What is one thing you can let be imperfect?",
+		),
+		Closing: lines(ClosingPause,
+			"Hold this intention lightly. Return to it when you remember.",
+			"Begin. 🌱",
+		),
+	},
+	{
+		Name:  "weekly",
+		Title: "🍂  End of Week",
+		Instructions: []Line{
+			{Text: "The week is nearly done. Let it settle...", Pause: InstructionPause},
+			{Text: "Take three deep breaths...", Pause: 5 * time.Second},
 		},
-	}
+		PromptTitle: "📝 Looking back:",
+		Prompts: lines(10*time.Second,
+			"What went well this week, however small?",
+			"What drained you, and what restored you?",
+			"What can you set down before the weekend?",
+		),
+		Closing: lines(ClosingPause,
+			"Whatever is unfinished will keep until Monday.",
+			"Rest well. 🙏",
+		),
+	},
+	{
+		Name:  "retro",
+		Title: "🌊  After the Incident",
+		Instructions: []Line{
+			{Text: "The incident is over. You are here, and you are safe.", Pause: InstructionPause},
+			{Text: "Unclench your jaw. Let your shoulders fall.", Pause: InstructionPause},
+			// Long exhales calm the body after adrenaline
+			{Text: "Take three breaths, each exhale longer than the inhale...", Pause: 8 * time.Second},
+		},
+		PromptTitle: "📝 Coming down gently:",
+		Prompts: lines(PromptPause,
+			"What is your body still holding on to?",
+			"What did you handle well under pressure?",
+			"What can wait until you have rested?",
+		),
+		Closing: lines(ClosingPause,
+			"The postmortem can wait. Blameless starts with yourself.",
+			"Step away for a few minutes if you can. 🙏",
+		),
+	},
 }
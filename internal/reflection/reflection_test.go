@@ -1,7 +1,11 @@
 package reflection
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/e6a5/zenta/internal/testutil"
 )
 
 func TestGetDefaultPrompts(t *testing.T) {
@@ -23,3 +27,66 @@ func TestGetDefaultPrompts(t *testing.T) {
 		t.Error("Expected Closing to not be empty")
 	}
 }
+
+func TestBuiltinSetsAreValid(t *testing.T) {
+	for _, set := range BuiltinSets() {
+		if err := set.Validate(); err != nil {
+			t.Errorf("Built-in set %q is invalid: %v", set.Name, err)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := testutil.WriteFile(t, t.TempDir(), "standup.yaml", `
+title: "☕ Before Standup"
+instructions:
+  - Sit back from the keyboard.
+prompts:
+  - text: What do you actually need from the team today?
+    pause: 20s
+  - What can you say in one sentence?
+closing:
+  - text: Go gently.
+    pause: 0s
+`)
+
+	set, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	if set.Name != "standup" || set.Source != path {
+		t.Errorf("Expected set named after its file, got %q from %q", set.Name, set.Source)
+	}
+	if set.Instructions[0].Pause != InstructionPause {
+		t.Errorf("Expected default instruction pause, got %v", set.Instructions[0].Pause)
+	}
+	if set.Prompts[0].Pause != 20*time.Second || set.Prompts[1].Pause != PromptPause {
+		t.Errorf("Unexpected prompt pauses: %v, %v", set.Prompts[0].Pause, set.Prompts[1].Pause)
+	}
+	if set.Closing[0].Pause != 0 {
+		t.Errorf("Expected an explicit zero pause to be kept, got %v", set.Closing[0].Pause)
+	}
+}
+
+func TestLoadFileRejectsInvalidSets(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		content string
+		want    string
+	}{
+		{"prompts: [Why]\n", "missing title"},
+		{"title: Empty\n", "no prompts"},
+		{"title: Typo\nprompt: [Why]\n", "line 2"},
+		{"title: Long\nprompts:\n  - text: Why?\n    pause: 1h\n", "pause must be"},
+		{"title: Blank\nprompts:\n  - \"\"\n", "prompt 1 has no text"},
+	}
+
+	for i, tc := range testCases {
+		path := testutil.WriteFile(t, dir, "set.yaml", tc.content)
+		_, err := LoadFile(path)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Case %d: expected error containing %q, got %v", i, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Package shellhook generates prompt hooks that gently suggest a breath.
+// The hooks track command durations and exit codes in the shell itself and
+// only invoke zenta when a heuristic might trigger, keeping prompts fast.
+package shellhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default thresholds for the prompt hook heuristics
+const (
+	DefaultFailures    = 3
+	DefaultLongCommand = 10 * time.Minute
+)
+
+// Exit statuses that don't count as failures: interrupted (Ctrl+C) and
+// suspended (Ctrl+Z) commands are the user's choice, not frustration.
+var ignoredStatuses = map[int]bool{130: true, 146: true, 148: true}
+
+// Options configures when the hook invites a breath
+type Options struct {
+	Failures    int           // Consecutive failed commands before nudging
+	LongCommand time.Duration // Commands running at least this long trigger a nudge
+}
+
+// DefaultOptions returns the recommended hook thresholds
+func DefaultOptions() Options {
+	return Options{
+		Failures:    DefaultFailures,
+		LongCommand: DefaultLongCommand,
+	}
+}
+
+// Stats describes the command that just finished, as reported by the hook
+type Stats struct {
+	Status   int           // Exit status of the last command
+	Elapsed  time.Duration // How long the last command ran
+	Failures int           // Consecutive failures, including the last command
+}
+
+// CountsAsFailure reports whether an exit status should add to the failure streak
+func CountsAsFailure(status int) bool {
+	return status != 0 && !ignoredStatuses[status]
+}
+
+// Evaluate decides whether to invite a breath and returns the invitation
+func Evaluate(stats Stats, opts Options) (string, bool) {
+	if opts.Failures > 0 && stats.Failures >= opts.Failures && CountsAsFailure(stats.Status) {
+		return fmt.Sprintf("🌬️  %d commands in a row didn't go as planned. A breath before the next try? → breath", stats.Failures), true
+	}
+
+	if opts.LongCommand > 0 && stats.Elapsed >= opts.LongCommand {
+		return fmt.Sprintf("🌬️  That took %s. Stretch, and take a breath? → breath", formatElapsed(stats.Elapsed)), true
+	}
+
+	return "", false
+}
+
+// formatElapsed renders a duration in whole minutes or seconds
+func formatElapsed(d time.Duration) string {
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// Shells returns the names of the supported shells
+func Shells() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Script returns the hook code for the given shell. The program is the
+// command used to call zenta back when a heuristic might trigger.
+func Script(shell, program string, opts Options) (string, error) {
+	tmpl, ok := templates[shell]
+	if !ok {
+		return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(Shells(), ", "))
+	}
+
+	replacer := strings.NewReplacer(
+		"@PROGRAM@", shellQuote(program),
+		"@FAILURES@", fmt.Sprint(thresholdFailures(opts)),
+		"@LONG@", fmt.Sprint(thresholdSeconds(opts)),
+	)
+	return replacer.Replace(tmpl), nil
+}
+
+// thresholdFailures returns the failure threshold, or an unreachable value when disabled
+func thresholdFailures(opts Options) int {
+	if opts.Failures <= 0 {
+		return 1 << 30
+	}
+	return opts.Failures
+}
+
+// thresholdSeconds returns the long-command threshold in seconds, or an unreachable value when disabled
+func thresholdSeconds(opts Options) int {
+	if opts.LongCommand <= 0 {
+		return 1 << 30
+	}
+	secs := int(opts.LongCommand / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return secs
+}
+
+// shellQuote wraps a value in single quotes, safe for bash, zsh and fish
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// The hooks only do integer arithmetic on the happy path; zenta itself is
+// invoked only when the failure streak or duration crosses a threshold.
+var templates = map[string]string{
+	"bash": `# zenta prompt hook for bash
+__zenta_failures=0
+__zenta_preexec() {
+  [ -n "$COMP_LINE" ] && return
+  [ -z "$__zenta_armed" ] && return
+  __zenta_armed=
+  __zenta_start=$SECONDS
+}
+__zenta_precmd() {
+  local st=$?
+  [ -z "$__zenta_start" ] && return
+  local elapsed=$((SECONDS - __zenta_start))
+  __zenta_start=
+  case $st in
+    0|130|146|148) __zenta_failures=0 ;;
+    *) __zenta_failures=$((__zenta_failures + 1)) ;;
+  esac
+  if [ "$__zenta_failures" -ge @FAILURES@ ] || [ "$elapsed" -ge @LONG@ ]; then
+    @PROGRAM@ shell-nudge --status "$st" --elapsed "$elapsed" --failures "$__zenta_failures" \
+      --after-failures @FAILURES@ --after @LONG@s && __zenta_failures=0
+  fi
+}
+__zenta_arm() {
+  __zenta_armed=1
+}
+if [[ -n ${bash_preexec_imported:-} || -n ${__bp_imported:-} ]]; then
+  # bash-preexec owns the DEBUG trap and PROMPT_COMMAND; join its hooks
+  precmd_functions=(__zenta_precmd "${precmd_functions[@]}" __zenta_arm)
+  preexec_functions+=(__zenta_preexec)
+else
+  # Chain onto an existing DEBUG trap rather than replacing it. A sourced
+  # file or function can't see the trap, so chain from the first prompt,
+  # traced so that it can.
+  __zenta_chain() {
+    __zenta_chained=1
+    local old
+    old=$(set -T; trap -p DEBUG) # set -T lets the subshell see the trap
+    old=${old#trap -- }
+    eval "old=${old% DEBUG}"
+    case $old in
+      *__zenta_preexec*) ;;
+      '') trap '__zenta_preexec' DEBUG ;;
+      *) trap "$old"$'\n''__zenta_preexec' DEBUG ;;
+    esac
+  }
+  declare -ft __zenta_chain
+  # PROMPT_COMMAND may be an array since bash 5.1
+  if [[ ${PROMPT_COMMAND[*]} == *__zenta_precmd* ]]; then
+    :
+  elif [[ $(declare -p PROMPT_COMMAND 2>/dev/null) == "declare -a"* ]]; then
+    PROMPT_COMMAND=(__zenta_precmd "${PROMPT_COMMAND[@]}" '[ -n "$__zenta_chained" ] || __zenta_chain' __zenta_arm)
+  else
+    PROMPT_COMMAND=$'__zenta_precmd\n'"${PROMPT_COMMAND:+$PROMPT_COMMAND$'\n'}"$'[ -n "$__zenta_chained" ] || __zenta_chain\n__zenta_arm'
+  fi
+fi
+`,
+	"zsh": `# zenta prompt hook for zsh
+typeset -gi __zenta_failures=0
+typeset -g __zenta_start=
+__zenta_preexec() {
+  __zenta_start=$SECONDS
+}
+__zenta_precmd() {
+  local st=$?
+  [[ -z $__zenta_start ]] && return
+  local elapsed=$(( ${SECONDS%.*} - ${__zenta_start%.*} ))
+  __zenta_start=
+  case $st in
+    0|130|146|148) __zenta_failures=0 ;;
+    *) (( __zenta_failures++ )) ;;
+  esac
+  if (( __zenta_failures >= @FAILURES@ || elapsed >= @LONG@ )); then
+    @PROGRAM@ shell-nudge --status "$st" --elapsed "$elapsed" --failures "$__zenta_failures" \
+      --after-failures @FAILURES@ --after @LONG@s && __zenta_failures=0
+  fi
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook preexec __zenta_preexec
+add-zsh-hook precmd __zenta_precmd
+`,
+	"fish": `# zenta prompt hook for fish
+set -g __zenta_failures 0
+function __zenta_postexec --on-event fish_postexec
+    set -l st $status
+    set -l elapsed (math --scale=0 $CMD_DURATION / 1000)
+    switch $st
+        case 0 130 146 148
+            set -g __zenta_failures 0
+        case '*'
+            set -g __zenta_failures (math $__zenta_failures + 1)
+    end
+    if test $__zenta_failures -ge @FAILURES@; or test $elapsed -ge @LONG@
+        @PROGRAM@ shell-nudge --status $st --elapsed $elapsed --failures $__zenta_failures \
+            --after-failures @FAILURES@ --after @LONG@s; and set -g __zenta_failures 0
+    end
+end
+`,
+}
@@ -0,0 +1,147 @@
+package shellhook
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	opts := DefaultOptions()
+
+	testCases := []struct {
+		name      string
+		stats     Stats
+		wantNudge bool
+	}{
+		{"success", Stats{Status: 0, Elapsed: time.Second}, false},
+		{"single failure", Stats{Status: 1, Failures: 1}, false},
+		{"failure streak", Stats{Status: 2, Failures: 3}, true},
+		{"interrupted streak", Stats{Status: 130, Failures: 3}, false},
+		{"long build", Stats{Status: 0, Elapsed: 12 * time.Minute}, true},
+		{"just under threshold", Stats{Status: 0, Elapsed: 10*time.Minute - time.Second}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message, ok := Evaluate(tc.stats, opts)
+			if ok != tc.wantNudge {
+				t.Errorf("Expected nudge %v, got %v (%q)", tc.wantNudge, ok, message)
+			}
+			if ok && !strings.Contains(message, "breath") {
+				t.Errorf("Expected invitation to mention breath, got %q", message)
+			}
+		})
+	}
+}
+
+func TestEvaluateDisabledThresholds(t *testing.T) {
+	opts := Options{}
+	if _, ok := Evaluate(Stats{Status: 1, Failures: 10, Elapsed: time.Hour}, opts); ok {
+		t.Error("Expected no nudge when all thresholds are disabled")
+	}
+}
+
+// placeholder matches a template placeholder such as @PROGRAM@
+var placeholder = regexp.MustCompile(`@[A-Z]+@`)
+
+func TestScript(t *testing.T) {
+	opts := Options{Failures: 4, LongCommand: 5 * time.Minute}
+
+	for _, shell := range Shells() {
+		t.Run(shell, func(t *testing.T) {
+			script, err := Script(shell, "zenta", opts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if placeholder.MatchString(script) {
+				t.Error("Expected all placeholders to be replaced")
+			}
+			if !strings.Contains(script, "'zenta' shell-nudge") {
+				t.Error("Expected script to call back into zenta")
+			}
+			if !strings.Contains(script, "--after-failures 4") || !strings.Contains(script, "--after 300s") {
+				t.Error("Expected script to embed the configured thresholds")
+			}
+		})
+	}
+}
+
+func TestBashScriptChainsHooks(t *testing.T) {
+	script, err := Script("bash", "zenta", DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"precmd_functions=(__zenta_precmd",                      // bash-preexec
+		`trap "$old"$'\n''__zenta_preexec' DEBUG`,               // An existing DEBUG trap
+		`PROMPT_COMMAND=(__zenta_precmd "${PROMPT_COMMAND[@]}"`, // An array PROMPT_COMMAND
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected the bash hook to contain %q", want)
+		}
+	}
+}
+
+func TestBashHookKeepsDebugTrap(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not installed")
+	}
+
+	// Echo stands in for zenta, so a nudge shows as its arguments
+	script, err := Script("bash", "echo", Options{Failures: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := filepath.Join(t.TempDir(), "hook.bash")
+	if err := os.WriteFile(hook, []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, load := range map[string]string{
+		"eval":     `eval "$(cat ` + hook + `)"`,
+		"source":   "source " + hook,
+		"function": "load() { source " + hook + "; }; load",
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := strings.Join([]string{
+				`trap '[[ $BASH_COMMAND == marker ]] && echo old trap' DEBUG`,
+				"marker() { :; }",
+				load,
+				"marker",
+				"false",
+				"exit 0",
+			}, "\n")
+			cmd := exec.Command(bash, "--norc", "--noprofile", "-i")
+			cmd.Stdin = strings.NewReader(input + "\n")
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("bash failed: %v", err)
+			}
+			if !strings.Contains(string(out), "old trap") {
+				t.Errorf("Expected the existing DEBUG trap to still fire, got %q", out)
+			}
+			if !strings.Contains(string(out), "shell-nudge --status 1") {
+				t.Errorf("Expected the hook to nudge after a failure, got %q", out)
+			}
+		})
+	}
+}
+
+func TestScriptUnsupportedShell(t *testing.T) {
+	if _, err := Script("tcsh", "zenta", DefaultOptions()); err == nil {
+		t.Error("Expected error for unsupported shell")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("Unexpected quoting: %s", got)
+	}
+}
@@ -0,0 +1,68 @@
+package sit
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Bell styles
+const (
+	BellBEL   = "bel"   // The terminal bell
+	BellFlash = "flash" // Briefly reverse the screen
+	BellBoth  = "both"
+	BellNone  = "none"
+)
+
+// flashLength is how long a visual bell reverses the screen
+const flashLength = 150 * time.Millisecond
+
+// Bell rings at the start, intervals and end of a sit
+type Bell struct {
+	Style   string // One of the Bell styles
+	Command string // A shell command to run instead, such as playing a sound
+}
+
+// ParseBellStyle checks a bell style name
+func ParseBellStyle(name string) (string, error) {
+	switch name {
+	case BellBEL, BellFlash, BellBoth, BellNone:
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown bell %q (use bel, flash, both or none)", name)
+}
+
+// Ring sounds the bell. A command runs in the background; its failure is
+// returned, but the sit goes on.
+func (b Bell) Ring(w io.Writer) error {
+	if b.Command != "" {
+		cmd := shellCommand(b.Command)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		go func() { _ = cmd.Wait() }()
+		return nil
+	}
+
+	if b.Style == BellBEL || b.Style == BellBoth {
+		fmt.Fprint(w, "\a")
+	}
+	if b.Style == BellFlash || b.Style == BellBoth {
+		fmt.Fprint(w, "\033[?5h")
+		time.Sleep(flashLength)
+		fmt.Fprint(w, "\033[?5l")
+	}
+	return nil
+}
+
+// shellCommand runs command through the user's shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		// #nosec G204 -- the user's own configured bell command
+		return exec.Command("cmd", "/C", command)
+	}
+	// #nosec G204 -- the user's own configured bell command
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,67 @@
+package sit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTimerPauses(t *testing.T) {
+	start := time.Now()
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	timer := NewTimer(60*time.Second, start)
+	timer.Toggle(at(10)) // Pause
+	if !timer.Paused() || timer.Elapsed(at(40)) != 10*time.Second {
+		t.Errorf("Expected 10s while paused, got %v", timer.Elapsed(at(40)))
+	}
+
+	timer.Toggle(at(40)) // Resume
+	if timer.Elapsed(at(45)) != 15*time.Second || timer.Remaining(at(45)) != 45*time.Second {
+		t.Errorf("Expected the pause left out, got %v", timer.Elapsed(at(45)))
+	}
+	if timer.Done(at(89)) || !timer.Done(at(90)) {
+		t.Error("Expected the sit to end 60s of sitting after the start")
+	}
+	if timer.Elapsed(at(200)) != 60*time.Second {
+		t.Errorf("Expected elapsed to stop at the length, got %v", timer.Elapsed(at(200)))
+	}
+}
+
+func TestIntervalsCrossed(t *testing.T) {
+	m := time.Minute
+	testCases := []struct {
+		from, to, interval, length time.Duration
+		want                       int
+	}{
+		{0, 4 * m, 5 * m, 20 * m, 0},
+		{4 * m, 5 * m, 5 * m, 20 * m, 1},
+		{5 * m, 6 * m, 5 * m, 20 * m, 0},   // Already rung
+		{0, 11 * m, 5 * m, 20 * m, 2},      // Catching up
+		{19 * m, 20 * m, 5 * m, 20 * m, 0}, // The end bell instead
+		{14 * m, 20 * m, 5 * m, 20 * m, 1},
+		{0, 20 * m, 0, 20 * m, 0}, // No intervals
+	}
+
+	for _, tc := range testCases {
+		if got := IntervalsCrossed(tc.from, tc.to, tc.interval, tc.length); got != tc.want {
+			t.Errorf("IntervalsCrossed(%v, %v, %v) = %d, want %d", tc.from, tc.to, tc.interval, got, tc.want)
+		}
+	}
+}
+
+func TestBellStyles(t *testing.T) {
+	var out bytes.Buffer
+	if err := (Bell{Style: BellBEL}).Ring(&out); err != nil || out.String() != "\a" {
+		t.Errorf("Expected a BEL, got %q, %v", out.String(), err)
+	}
+
+	out.Reset()
+	if err := (Bell{Style: BellNone}).Ring(&out); err != nil || out.Len() != 0 {
+		t.Errorf("Expected silence, got %q, %v", out.String(), err)
+	}
+
+	if _, err := ParseBellStyle("gong"); err == nil {
+		t.Error("Expected an error for an unknown bell")
+	}
+}
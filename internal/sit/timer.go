@@ -0,0 +1,69 @@
+// Package sit times silent meditation: a pausable timer, the interval
+// bells along the way, and the bells themselves.
+package sit
+
+import "time"
+
+// Timer measures a sit, leaving out any time spent paused
+type Timer struct {
+	Length  time.Duration
+	elapsed time.Duration // Time sat before the last pause
+	resumed time.Time     // When sitting last resumed
+	paused  bool
+}
+
+// NewTimer starts timing a sit of length at now
+func NewTimer(length time.Duration, now time.Time) *Timer {
+	return &Timer{Length: length, resumed: now}
+}
+
+// Elapsed returns how long has been sat, never more than the length
+func (t *Timer) Elapsed(now time.Time) time.Duration {
+	elapsed := t.elapsed
+	if !t.paused {
+		elapsed += now.Sub(t.resumed)
+	}
+	return min(elapsed, t.Length)
+}
+
+// Remaining returns how much of the sit is left
+func (t *Timer) Remaining(now time.Time) time.Duration {
+	return t.Length - t.Elapsed(now)
+}
+
+// Done reports whether the whole sit has passed
+func (t *Timer) Done(now time.Time) bool {
+	return t.Elapsed(now) >= t.Length
+}
+
+// Paused reports whether the timer is paused
+func (t *Timer) Paused() bool {
+	return t.paused
+}
+
+// Toggle pauses a running timer, or resumes a paused one
+func (t *Timer) Toggle(now time.Time) {
+	if t.paused {
+		t.resumed = now
+	} else {
+		t.elapsed += now.Sub(t.resumed)
+	}
+	t.paused = !t.paused
+}
+
+// IntervalsCrossed counts the interval bells due between two points in a
+// sit: every multiple of interval after from, up to and including to,
+// except the end of the sit, which has its own bell
+func IntervalsCrossed(from, to, interval, length time.Duration) int {
+	if interval <= 0 || to <= from {
+		return 0
+	}
+	last := to
+	if last >= length {
+		last = length - 1
+	}
+	if last <= from {
+		return 0
+	}
+	return int(last/interval - from/interval)
+}
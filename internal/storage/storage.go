@@ -0,0 +1,95 @@
+// Package storage locates and persists zenta's files on disk.
+// It follows the XDG base directory specification, so configuration lives
+// under $XDG_CONFIG_HOME/zenta (usually ~/.config/zenta), small pieces of
+// state under $XDG_STATE_HOME/zenta (usually ~/.local/state/zenta) and the
+// user's own records, such as written reflections, under
+// $XDG_DATA_HOME/zenta (usually ~/.local/share/zenta).
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// AppName is the directory name used inside each base directory
+const AppName = "zenta"
+
+// ConfigDir returns the directory holding user configuration and quote files
+func ConfigDir() string {
+	return baseDir("XDG_CONFIG_HOME", ".config")
+}
+
+// StateDir returns the directory holding state that persists between runs,
+// such as quote rotation
+func StateDir() string {
+	return baseDir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// DataDir returns the directory holding the user's own records, such as
+// written reflections
+func DataDir() string {
+	return baseDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// baseDir resolves an XDG base directory, falling back to a path under $HOME
+func baseDir(envVar, fallback string) string {
+	if dir := os.Getenv(envVar); filepath.IsAbs(dir) {
+		return filepath.Join(dir, AppName)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), AppName)
+	}
+	return filepath.Join(home, fallback, AppName)
+}
+
+// ReadJSON decodes the JSON file at path into v. It returns false without
+// an error when the file doesn't exist yet.
+func ReadJSON(path string, v interface{}) (bool, error) {
+	// #nosec G304 -- paths come from zenta's own directories
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// WriteJSON atomically writes v to path as indented JSON, creating parent
+// directories as needed. Files are private to the user.
+func WriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, append(data, '\n'))
+}
+
+// WriteFileAtomic writes data to a temporary file and renames it into
+// place, so a crash never leaves a half-written file behind.
+func WriteFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigDirHonorsXDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg-config")
+
+	if got := ConfigDir(); got != filepath.Join("/tmp/xdg-config", AppName) {
+		t.Errorf("Expected XDG config dir, got %s", got)
+	}
+}
+
+func TestConfigDirFallsBackToHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "relative/paths/are/ignored")
+	t.Setenv("HOME", "/tmp/home")
+
+	if got := ConfigDir(); got != filepath.Join("/tmp/home", ".config", AppName) {
+		t.Errorf("Expected fallback under HOME, got %s", got)
+	}
+}
+
+func TestStateDirHonorsXDG(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/xdg-state")
+
+	if got := StateDir(); got != filepath.Join("/tmp/xdg-state", AppName) {
+		t.Errorf("Expected XDG state dir, got %s", got)
+	}
+}
+
+func TestDataDirHonorsXDG(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg-data")
+
+	if got := DataDir(); got != filepath.Join("/tmp/xdg-data", AppName) {
+		t.Errorf("Expected XDG data dir, got %s", got)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	var missing map[string]int
+	if found, err := ReadJSON(path, &missing); found || err != nil {
+		t.Fatalf("Expected missing file to be reported as not found, got found=%v err=%v", found, err)
+	}
+
+	if err := WriteJSON(path, map[string]int{"breaths": 3}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	var got map[string]int
+	if found, err := ReadJSON(path, &got); !found || err != nil {
+		t.Fatalf("ReadJSON failed: found=%v err=%v", found, err)
+	}
+	if got["breaths"] != 3 {
+		t.Errorf("Expected round-tripped value 3, got %v", got)
+	}
+}
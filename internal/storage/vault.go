@@ -0,0 +1,432 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// VaultFile holds the vault's key derivation settings in the data directory
+const VaultFile = "vault.json"
+
+// vaultMagic starts every encrypted file
+const vaultMagic = "zenta-vault-v1\n"
+
+// keySize is the AES-256 key length
+const keySize = 32
+
+// checkText is sealed with the data key in the vault header, to recognize
+// a kept key that still belongs to this vault
+const checkText = "zenta"
+
+// scrypt cost parameters for new vaults, about 100ms and 32MB to derive
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Vault errors
+var (
+	ErrLocked        = errors.New("the vault is locked; run 'zenta vault unlock' first")
+	ErrNoVault       = errors.New("there is no vault yet; run 'zenta vault lock' to create one")
+	ErrWrongPassword = errors.New("wrong passphrase")
+	ErrNoKeyCache    = errors.New("there is nowhere private to keep the vault key between commands")
+)
+
+// sessionKey is the vault key for this process alone, when it can't be
+// kept in the runtime directory
+var sessionKey []byte
+
+// vaultHeader holds the random key that encrypts data files, itself
+// sealed with a key derived from the passphrase. Changing the passphrase
+// only rewrites the header.
+type vaultHeader struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	WrappedKey []byte `json:"wrapped_key"` // Data key sealed with the passphrase key
+	Check      []byte `json:"check"`       // checkText sealed with the data key
+}
+
+// VaultEnabled reports whether data files are encrypted
+func VaultEnabled() bool {
+	_, err := os.Stat(vaultPath())
+	return err == nil
+}
+
+// VaultUnlocked reports whether the vault key is available in this session
+func VaultUnlocked() bool {
+	_, err := vaultKey()
+	return err == nil
+}
+
+// CreateVault sets up encryption with a new passphrase and encrypts the
+// existing data files. It returns how many files were encrypted.
+func CreateVault(passphrase []byte) (int, error) {
+	if VaultEnabled() {
+		return 0, fmt.Errorf("a vault already exists")
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return 0, err
+	}
+	header, err := newVaultHeader(passphrase, key)
+	if err != nil {
+		return 0, err
+	}
+
+	// Write the header first, so no file is ever sealed with a lost key
+	if err := WriteJSON(vaultPath(), header); err != nil {
+		return 0, err
+	}
+	return sealDataFiles(key)
+}
+
+// UnlockVault checks the passphrase and keeps the key for this login
+// session, in the runtime directory. Without a private runtime directory
+// the key lasts only as long as this process, and UnlockVault returns an
+// error wrapping ErrNoKeyCache.
+func UnlockVault(passphrase []byte) error {
+	header, err := readVaultHeader()
+	if err != nil {
+		return err
+	}
+	key, err := header.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
+	sessionKey = key
+
+	path, err := keyCachePath()
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, key)
+}
+
+// CanKeepKey reports whether an unlocked vault stays unlocked between
+// commands
+func CanKeepKey() bool {
+	_, err := keyCachePath()
+	return err == nil
+}
+
+// LockVault encrypts any data files still in plain text and forgets the
+// key. It returns how many files were encrypted.
+func LockVault() (int, error) {
+	key, err := vaultKey()
+	if err != nil {
+		return 0, err
+	}
+	count, err := sealDataFiles(key)
+	if err != nil {
+		return count, err
+	}
+	return count, forgetKey()
+}
+
+// RekeyVault changes the vault passphrase. The data files keep their key,
+// so only the vault header is rewritten.
+func RekeyVault(oldPassphrase, newPassphrase []byte) error {
+	header, err := readVaultHeader()
+	if err != nil {
+		return err
+	}
+	key, err := header.deriveKey(oldPassphrase)
+	if err != nil {
+		return err
+	}
+
+	newHeader, err := newVaultHeader(newPassphrase, key)
+	if err != nil {
+		return err
+	}
+	return WriteJSON(vaultPath(), newHeader)
+}
+
+// ReadData reads a file in the data directory, decrypting it when it was
+// written to the vault. It returns false without an error when the file
+// doesn't exist yet.
+func ReadData(path string) ([]byte, bool, error) {
+	// #nosec G304 -- paths come from zenta's own data directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !isSealed(data) {
+		return data, true, nil
+	}
+
+	key, err := vaultKey()
+	if err != nil {
+		return nil, false, err
+	}
+	plain, err := open(key, data, dataName(path))
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	return plain, true, nil
+}
+
+// WriteData atomically writes a file in the data directory, encrypting it
+// when the vault is enabled. Writing to a locked vault fails with
+// ErrLocked rather than leaving the file in plain text.
+func WriteData(path string, data []byte) error {
+	if !VaultEnabled() {
+		return WriteFileAtomic(path, data)
+	}
+	key, err := vaultKey()
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(key, data, dataName(path))
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, sealed)
+}
+
+// newVaultHeader seals key under passphrase with a fresh salt
+func newVaultHeader(passphrase, key []byte) (vaultHeader, error) {
+	header := vaultHeader{Version: 1, KDF: "scrypt", Salt: make([]byte, 16), N: scryptN, R: scryptR, P: scryptP}
+	if _, err := rand.Read(header.Salt); err != nil {
+		return header, err
+	}
+
+	passKey, err := scrypt.Key(passphrase, header.Salt, header.N, header.R, header.P, keySize)
+	if err != nil {
+		return header, err
+	}
+	if header.WrappedKey, err = seal(passKey, key, VaultFile); err != nil {
+		return header, err
+	}
+	header.Check, err = seal(key, []byte(checkText), VaultFile)
+	return header, err
+}
+
+// deriveKey unseals the data key with passphrase
+func (h vaultHeader) deriveKey(passphrase []byte) ([]byte, error) {
+	passKey, err := scrypt.Key(passphrase, h.Salt, h.N, h.R, h.P, keySize)
+	if err != nil {
+		return nil, err
+	}
+	key, err := open(passKey, h.WrappedKey, VaultFile)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return key, nil
+}
+
+// verify checks that key is this vault's data key
+func (h vaultHeader) verify(key []byte) error {
+	plain, err := open(key, h.Check, VaultFile)
+	if err != nil || subtle.ConstantTimeCompare(plain, []byte(checkText)) != 1 {
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// readVaultHeader loads the vault's key derivation settings
+func readVaultHeader() (vaultHeader, error) {
+	var header vaultHeader
+	found, err := ReadJSON(vaultPath(), &header)
+	if err != nil {
+		return header, fmt.Errorf("%s: %w", vaultPath(), err)
+	}
+	if !found {
+		return header, ErrNoVault
+	}
+	if header.Version != 1 || header.KDF != "scrypt" {
+		return header, fmt.Errorf("%s: unsupported vault version %d (%s)", vaultPath(), header.Version, header.KDF)
+	}
+	return header, nil
+}
+
+// vaultKey returns the key kept by UnlockVault, if it still opens the vault
+func vaultKey() ([]byte, error) {
+	header, err := readVaultHeader()
+	if err != nil {
+		return nil, err
+	}
+	if sessionKey != nil && header.verify(sessionKey) == nil {
+		return sessionKey, nil
+	}
+
+	path, err := keyCachePath()
+	if err != nil {
+		return nil, ErrLocked
+	}
+	// #nosec G304 -- path is zenta's own runtime file
+	key, err := os.ReadFile(path)
+	if err != nil || len(key) != keySize || header.verify(key) != nil {
+		return nil, ErrLocked
+	}
+	return key, nil
+}
+
+// forgetKey removes the kept key, locking the vault
+func forgetKey() error {
+	sessionKey = nil
+	path, err := keyCachePath()
+	if err != nil {
+		return nil // Nothing was kept
+	}
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// sealDataFiles encrypts every data file still in plain text with key,
+// returning how many it encrypted
+func sealDataFiles(key []byte) (int, error) {
+	count := 0
+	err := filepath.WalkDir(DataDir(), func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == DataDir() {
+			return filepath.SkipDir // Nothing stored yet
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path == vaultPath() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		// #nosec G304 -- walking zenta's own data directory
+		data, err := os.ReadFile(path)
+		if err != nil || isSealed(data) {
+			return err
+		}
+
+		sealed, err := seal(key, data, dataName(path))
+		if err != nil {
+			return err
+		}
+		if err := WriteFileAtomic(path, sealed); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// seal encrypts data with AES-256-GCM. The file name is authenticated too,
+// so sealed files can't be swapped for one another unnoticed.
+func seal(key, data []byte, name string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(vaultMagic), nonce...)
+	return aead.Seal(out, nonce, data, []byte(name)), nil
+}
+
+// open decrypts data sealed by seal
+func open(key, data []byte, name string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte(vaultMagic))
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted file is truncated")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return nil, errors.New("cannot decrypt: damaged, or sealed with another key")
+	}
+	return plain, nil
+}
+
+// newAEAD returns AES-256-GCM for key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// isSealed reports whether data was written by seal
+func isSealed(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(vaultMagic))
+}
+
+// dataName is a file's path relative to the data directory
+func dataName(path string) string {
+	if rel, err := filepath.Rel(DataDir(), path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(path)
+}
+
+// vaultPath is the location of the vault header
+func vaultPath() string {
+	return filepath.Join(DataDir(), VaultFile)
+}
+
+// keyCachePath is where an unlocked vault's key is kept: the runtime
+// directory, which is cleared when the user logs out. The key is only
+// kept there once the directory is confirmed private to the user.
+func keyCachePath() (string, error) {
+	runtime := os.Getenv("XDG_RUNTIME_DIR")
+	if !filepath.IsAbs(runtime) {
+		return "", fmt.Errorf("%w: XDG_RUNTIME_DIR is not set", ErrNoKeyCache)
+	}
+	if err := checkPrivate(runtime); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(runtime, AppName)
+	if err := os.Mkdir(dir, 0o700); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", err
+	}
+	if err := checkPrivate(dir); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vault.key"), nil
+}
+
+// checkPrivate makes sure dir is a real directory that belongs to the
+// user and that no one else can open
+func checkPrivate(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNoKeyCache, err)
+	}
+	switch {
+	case !info.IsDir():
+		return fmt.Errorf("%w: %s is not a directory", ErrNoKeyCache, dir)
+	case !ownedByUser(info):
+		return fmt.Errorf("%w: %s belongs to another user", ErrNoKeyCache, dir)
+	case info.Mode().Perm() != 0o700:
+		return fmt.Errorf("%w: %s is open to others (mode %04o)", ErrNoKeyCache, dir, info.Mode().Perm())
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupVault points the data and runtime directories at temporary ones
+// and makes key derivation cheap
+func setupVault(t *testing.T) string {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	runtime := t.TempDir()
+	if err := os.Chmod(runtime, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", runtime)
+
+	oldN := scryptN
+	scryptN = 1 << 10
+	t.Cleanup(func() { scryptN = oldN })
+
+	return DataDir()
+}
+
+func TestVaultLifecycle(t *testing.T) {
+	dir := setupVault(t)
+	journal := filepath.Join(dir, "reflections", "2026-10-18.md")
+	secret := []byte("What pulled me away: the pager.\n")
+
+	if err := WriteData(journal, secret); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := CreateVault([]byte("correct horse"))
+	if err != nil {
+		t.Fatalf("CreateVault failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 file encrypted, got %d", count)
+	}
+
+	raw, _ := os.ReadFile(journal)
+	if bytes.Contains(raw, []byte("pager")) {
+		t.Error("Expected the journal to be encrypted on disk")
+	}
+
+	// Locked: reads and writes refuse
+	if _, _, err := ReadData(journal); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked reading a locked vault, got %v", err)
+	}
+	if err := WriteData(journal, secret); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked writing to a locked vault, got %v", err)
+	}
+
+	if err := UnlockVault([]byte("wrong horse")); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected ErrWrongPassword, got %v", err)
+	}
+	if err := UnlockVault([]byte("correct horse")); err != nil {
+		t.Fatalf("UnlockVault failed: %v", err)
+	}
+
+	data, found, err := ReadData(journal)
+	if err != nil || !found || !bytes.Equal(data, secret) {
+		t.Errorf("Expected transparent decryption, got %q, %v, %v", data, found, err)
+	}
+
+	// Unlocked writes are encrypted too
+	notes := filepath.Join(dir, "notes.json")
+	if err := WriteData(notes, []byte(`{"planning": 3}`)); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := os.ReadFile(notes); !isSealed(raw) {
+		t.Error("Expected writes to an unlocked vault to be encrypted")
+	}
+
+	if _, err := LockVault(); err != nil {
+		t.Fatalf("LockVault failed: %v", err)
+	}
+	if VaultUnlocked() {
+		t.Error("Expected the vault to be locked")
+	}
+}
+
+func TestVaultRekey(t *testing.T) {
+	dir := setupVault(t)
+	path := filepath.Join(dir, "history.json")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("[]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateVault([]byte("first passphrase")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RekeyVault([]byte("not it"), []byte("second passphrase")); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected ErrWrongPassword, got %v", err)
+	}
+	if err := RekeyVault([]byte("first passphrase"), []byte("second passphrase")); err != nil {
+		t.Fatalf("RekeyVault failed: %v", err)
+	}
+
+	if err := UnlockVault([]byte("first passphrase")); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected the old passphrase to stop working, got %v", err)
+	}
+	if err := UnlockVault([]byte("second passphrase")); err != nil {
+		t.Fatalf("UnlockVault failed: %v", err)
+	}
+	if data, _, err := ReadData(path); err != nil || string(data) != "[]" {
+		t.Errorf("Expected files to open under the new passphrase, got %q, %v", data, err)
+	}
+}
+
+func TestSealedFilesCannotBeSwapped(t *testing.T) {
+	dir := setupVault(t)
+	if _, err := CreateVault([]byte("correct horse")); err != nil {
+		t.Fatal(err)
+	}
+	if err := UnlockVault([]byte("correct horse")); err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")
+	if err := WriteData(a, []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(a)
+	if err := os.WriteFile(b, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ReadData(b); err == nil {
+		t.Error("Expected a file copied under another name to fail to decrypt")
+	}
+}
+
+func TestKeyNotKeptOutsidePrivateDir(t *testing.T) {
+	setupVault(t)
+	if _, err := CreateVault([]byte("correct horse")); err != nil {
+		t.Fatal(err)
+	}
+
+	shared := t.TempDir()
+	if err := os.Chmod(shared, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, runtime := range map[string]string{"unset": "", "shared": shared} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_RUNTIME_DIR", runtime)
+			if CanKeepKey() {
+				t.Error("Expected nowhere to keep the key")
+			}
+
+			if err := UnlockVault([]byte("correct horse")); !errors.Is(err, ErrNoKeyCache) {
+				t.Errorf("Expected ErrNoKeyCache, got %v", err)
+			}
+			if !VaultUnlocked() {
+				t.Error("Expected the key to last for this process")
+			}
+			if entries, _ := os.ReadDir(shared); len(entries) != 0 {
+				t.Errorf("Expected nothing written to a shared directory, got %d entries", len(entries))
+			}
+
+			if _, err := LockVault(); err != nil {
+				t.Fatalf("LockVault failed: %v", err)
+			}
+			if VaultUnlocked() {
+				t.Error("Expected locking to forget the key")
+			}
+		})
+	}
+}
+
+func TestReadDataWithoutVault(t *testing.T) {
+	dir := setupVault(t)
+
+	if _, found, err := ReadData(filepath.Join(dir, "missing.md")); found || err != nil {
+		t.Errorf("Expected a missing file to be reported as not found, got %v, %v", found, err)
+	}
+	if _, err := LockVault(); !errors.Is(err, ErrNoVault) {
+		t.Errorf("Expected ErrNoVault, got %v", err)
+	}
+}
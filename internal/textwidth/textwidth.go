@@ -0,0 +1,184 @@
+// Package textwidth measures how many terminal columns text occupies,
+// one user-perceived character at a time.
+package textwidth
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Special code points that glue grapheme clusters together
+const (
+	zeroWidthJoiner = 0x200D
+	emojiVariation  = 0xFE0F // Requests emoji (wide) presentation
+)
+
+// wideRanges lists East Asian Wide and Fullwidth characters, plus symbols
+// that terminals draw as emoji by default. Each pair is an inclusive range.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x231A, 0x231B},   // ⌚⌛
+	{0x23E9, 0x23EC},   // ⏩⏬
+	{0x23F0, 0x23F0},   // ⏰
+	{0x23F3, 0x23F3},   // ⏳
+	{0x25FD, 0x25FE},   // ◽◾
+	{0x2614, 0x2615},   // ☔☕
+	{0x2648, 0x2653},   // Zodiac
+	{0x267F, 0x267F},   // ♿
+	{0x2693, 0x2693},   // ⚓
+	{0x26A1, 0x26A1},   // ⚡
+	{0x26AA, 0x26AB},   // ⚪⚫
+	{0x26BD, 0x26BE},   // ⚽⚾
+	{0x26C4, 0x26C5},   // ⛄⛅
+	{0x26CE, 0x26CE},   // ⛎
+	{0x26D4, 0x26D4},   // ⛔
+	{0x26EA, 0x26EA},   // ⛪
+	{0x26F2, 0x26F3},   // ⛲⛳
+	{0x26F5, 0x26F5},   // ⛵
+	{0x26FA, 0x26FA},   // ⛺
+	{0x26FD, 0x26FD},   // ⛽
+	{0x2705, 0x2705},   // ✅
+	{0x270A, 0x270B},   // ✊✋
+	{0x2728, 0x2728},   // ✨
+	{0x274C, 0x274C},   // ❌
+	{0x274E, 0x274E},   // ❎
+	{0x2753, 0x2755},   // ❓❔❕
+	{0x2757, 0x2757},   // ❗
+	{0x2795, 0x2797},   // ➕➖➗
+	{0x27B0, 0x27B0},   // ➰
+	{0x27BF, 0x27BF},   // ➿
+	{0x2B1B, 0x2B1C},   // ⬛⬜
+	{0x2B50, 0x2B50},   // ⭐
+	{0x2B55, 0x2B55},   // ⭕
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Kana, CJK symbols
+	{0x3400, 0x4DBF},   // CJK Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F004, 0x1F004}, // 🀄
+	{0x1F0CF, 0x1F0CF}, // 🃏
+	{0x1F18E, 0x1F18E}, // 🆎
+	{0x1F191, 0x1F19A}, // 🆑-🆚
+	{0x1F1E6, 0x1F1FF}, // Regional indicators (flags)
+	{0x1F200, 0x1F2FF}, // Enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, // Misc symbols and pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map
+	{0x1F7E0, 0x1F7EB}, // Colored circles and squares
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended-A
+	{0x20000, 0x3FFFD}, // CJK Extensions B and beyond
+}
+
+// Graphemes splits s into user-perceived characters. It handles combining
+// marks, variation selectors, skin tones, ZWJ emoji sequences and flag
+// pairs, which covers what quotes and notes realistically contain.
+func Graphemes(s string) []string {
+	var clusters []string
+	start := 0
+	var prev rune = -1
+	regionalRun := 0
+
+	for i, r := range s {
+		if i > 0 && !extendsCluster(prev, r, regionalRun) {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+
+		if isRegionalIndicator(r) {
+			regionalRun++
+		} else {
+			regionalRun = 0
+		}
+		prev = r
+	}
+
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// extendsCluster reports whether r continues the cluster ending in prev
+func extendsCluster(prev, r rune, regionalRun int) bool {
+	switch {
+	case prev == zeroWidthJoiner:
+		return true // ZWJ glues the next character on
+	case r == zeroWidthJoiner, isVariationSelector(r), isSkinTone(r), isEmojiTag(r):
+		return true
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true // Combining marks
+	case isRegionalIndicator(r) && isRegionalIndicator(prev):
+		return regionalRun%2 == 1 // Flags are pairs of regional indicators
+	}
+	return false
+}
+
+// String returns the number of terminal columns s occupies
+func String(s string) int {
+	width := 0
+	for _, cluster := range Graphemes(s) {
+		width += Cluster(cluster)
+	}
+	return width
+}
+
+// Cluster returns the number of terminal columns one grapheme occupies
+func Cluster(cluster string) int {
+	base, size := utf8.DecodeRuneInString(cluster)
+	rest := cluster[size:]
+
+	// ZWJ sequences, flags and emoji presentation selectors render as one wide glyph
+	for _, r := range rest {
+		if r == emojiVariation || r == zeroWidthJoiner || isRegionalIndicator(r) {
+			return 2
+		}
+	}
+	return Rune(base)
+}
+
+// Rune returns the column width of a single character
+func Rune(r rune) int {
+	switch {
+	case r == 0 || unicode.IsControl(r):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf), isVariationSelector(r):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether r falls in one of the wide ranges
+func isWide(r rune) bool {
+	if r < wideRanges[0][0] {
+		return false
+	}
+	for _, rng := range wideRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func isVariationSelector(r rune) bool {
+	return (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF)
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isEmojiTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
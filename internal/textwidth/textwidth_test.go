@@ -0,0 +1,56 @@
+package textwidth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"ascii", "breathe", 7},
+		{"em dash", "mind—not", 8},
+		{"precomposed accent", "Émile", 5},
+		{"combining accent", "E\u0301mile", 5},
+		{"cjk", "静かな心", 8},
+		{"hangul", "숨", 2},
+		{"fullwidth punctuation", "心。", 4},
+		{"emoji", "🌸", 2},
+		{"emoji with variation selector", "🕊️", 2},
+		{"text symbol with emoji selector", "⚖️", 2},
+		{"bare text symbol", "⚖", 1},
+		{"zwj family", "👨‍👩‍👧", 2},
+		{"skin tone", "🙏🏽", 2},
+		{"flag", "🇯🇵", 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := String(tc.input); got != tc.want {
+				t.Errorf("String(%q) = %d, want %d", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  int
+	}{
+		{"abc", 3},
+		{"é", 1},
+		{"👨‍👩‍👧", 1},
+		{"🇯🇵🇫🇷", 2},
+		{"🙏🏽!", 2},
+	}
+
+	for _, tc := range testCases {
+		if got := Graphemes(tc.input); len(got) != tc.want || strings.Join(got, "") != tc.input {
+			t.Errorf("Graphemes(%q) = %q, want %d clusters", tc.input, got, tc.want)
+		}
+	}
+}
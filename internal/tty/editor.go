@@ -0,0 +1,129 @@
+package tty
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/e6a5/zenta/internal/textwidth"
+)
+
+// LineResult says how editing a line ended
+type LineResult int
+
+// Ways a line edit can end
+const (
+	LineEntered   LineResult = iota // Enter was pressed
+	LineBack                        // Up arrow: return to the previous line
+	LineCancelled                   // Ctrl+C, or input closed
+)
+
+// Further key codes understood by the line editor
+const (
+	CtrlH = 8  // Backspace on some terminals
+	CtrlU = 21 // Clear the line
+	CtrlW = 23 // Delete the previous word
+)
+
+// escapeTimeout separates a lone Escape key from an arrow key sequence
+const escapeTimeout = 50 * time.Millisecond
+
+// ReadLine lets the user edit one line of text, starting from initial,
+// with keys from keys echoed to w. The terminal must be in raw mode.
+// It supports backspace, Ctrl+U and Ctrl+W, and multi-byte characters.
+func ReadLine(keys <-chan byte, w io.Writer, initial string) (string, LineResult) {
+	text := []rune(initial)
+	io.WriteString(w, initial)
+
+	var pending []byte // Bytes of a character still arriving
+	// erase removes whole characters from the end until at most keep runes
+	// remain, clearing every cell a wide one occupied
+	erase := func(keep int) {
+		for len(text) > keep {
+			clusters := textwidth.Graphemes(string(text))
+			last := clusters[len(clusters)-1]
+			text = text[:len(text)-utf8.RuneCountInString(last)]
+			width := textwidth.Cluster(last)
+			io.WriteString(w, strings.Repeat("\b", width)+strings.Repeat(" ", width)+strings.Repeat("\b", width))
+		}
+	}
+
+	for {
+		key, ok := <-keys
+		if !ok {
+			return string(text), LineCancelled
+		}
+
+		if len(pending) > 0 || key >= utf8.RuneSelf {
+			pending = append(pending, key)
+			if !utf8.FullRune(pending) {
+				continue
+			}
+			r, _ := utf8.DecodeRune(pending)
+			pending = pending[:0]
+			if r != utf8.RuneError && (unicode.IsPrint(r) || unicode.Is(unicode.Mn, r)) {
+				text = append(text, r)
+				io.WriteString(w, string(r))
+			}
+			continue
+		}
+
+		switch key {
+		case Enter, '\n':
+			return string(text), LineEntered
+		case CtrlC:
+			return string(text), LineCancelled
+		case Backspace, CtrlH:
+			erase(len(text) - 1)
+		case CtrlU:
+			erase(0)
+		case CtrlW:
+			end := len(text)
+			for end > 0 && text[end-1] == ' ' {
+				end--
+			}
+			for end > 0 && text[end-1] != ' ' {
+				end--
+			}
+			erase(end)
+		case Escape:
+			if readEscape(keys) == 'A' {
+				return string(text), LineBack
+			}
+		case '\t':
+			text = append(text, ' ')
+			io.WriteString(w, " ")
+		default:
+			if key >= ' ' {
+				text = append(text, rune(key))
+				w.Write([]byte{key})
+			}
+		}
+	}
+}
+
+// readEscape reads the rest of an escape sequence like "[A" and returns
+// its final byte, or 0 for a lone Escape key
+func readEscape(keys <-chan byte) byte {
+	next := func() byte {
+		select {
+		case key := <-keys:
+			return key
+		case <-time.After(escapeTimeout):
+			return 0
+		}
+	}
+
+	if next() != '[' {
+		return 0
+	}
+	for {
+		key := next()
+		// Parameters and intermediates come before the final byte
+		if key < '0' || key > '?' {
+			return key
+		}
+	}
+}
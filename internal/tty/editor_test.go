@@ -0,0 +1,70 @@
+package tty
+
+import (
+	"bytes"
+	"testing"
+)
+
+func feed(input string, closeAfter bool) <-chan byte {
+	keys := make(chan byte, len(input)+1)
+	for i := 0; i < len(input); i++ {
+		keys <- input[i]
+	}
+	if closeAfter {
+		close(keys)
+	}
+	return keys
+}
+
+func TestReadLine(t *testing.T) {
+	testCases := []struct {
+		name    string
+		initial string
+		input   string
+		want    string
+		result  LineResult
+	}{
+		{"typed", "", "planning\r", "planning", LineEntered},
+		{"backspace", "", "plann\x7fing\r", "planing", LineEntered},
+		{"multi-byte", "", "café 静か\r", "café 静か", LineEntered},
+		{"erase multi-byte", "", "静か\x7f\r", "静", LineEntered},
+		{"erase whole emoji", "", "a🙏🏽\x7f\r", "a", LineEntered},
+		{"clear line", "draft", "\x15done\r", "done", LineEntered},
+		{"delete word", "", "too many words\x17\r", "too many ", LineEntered},
+		{"edit initial", "first", " draft\r", "first draft", LineEntered},
+		{"up arrow", "", "half\x1b[A", "half", LineBack},
+		{"alt key ignored", "", "a\x1bb\r", "a", LineEntered},
+		{"ctrl-c", "", "no\x03", "no", LineCancelled},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, result := ReadLine(feed(tc.input, false), &out, tc.initial)
+			if got != tc.want || result != tc.result {
+				t.Errorf("ReadLine(%q) = %q, %v; want %q, %v", tc.input, got, result, tc.want, tc.result)
+			}
+		})
+	}
+}
+
+func TestReadLineClosedInput(t *testing.T) {
+	got, result := ReadLine(feed("partial", true), &bytes.Buffer{}, "")
+	if got != "partial" || result != LineCancelled {
+		t.Errorf("Expected closed input to cancel with the text so far, got %q, %v", got, result)
+	}
+}
+
+func TestReadLineEchoesErase(t *testing.T) {
+	var out bytes.Buffer
+	ReadLine(feed("ab\x7f\r", false), &out, "")
+	if got := out.String(); got != "ab\b \b" {
+		t.Errorf("Unexpected echo %q", got)
+	}
+
+	out.Reset()
+	ReadLine(feed("静\x7f\r", false), &out, "")
+	if got := out.String(); got != "静\b\b  \b\b" {
+		t.Errorf("Expected both cells of a wide character cleared, got %q", got)
+	}
+}
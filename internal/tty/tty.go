@@ -0,0 +1,112 @@
+// Package tty provides keyboard input shared by zenta's interactive modes.
+// A single reader goroutine serves the whole process, so one mode ending
+// never leaves a stray reader behind to swallow the next mode's keys.
+package tty
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Key codes used across interactive modes
+const (
+	CtrlC     = 3
+	Enter     = '\r'
+	Backspace = 127
+	Escape    = 27
+)
+
+var (
+	startOnce sync.Once
+	keys      chan byte
+)
+
+// IsTerminal reports whether f is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// IsInteractive reports whether both stdin and stdout are terminals
+func IsInteractive() bool {
+	return IsTerminal(os.Stdin) && IsTerminal(os.Stdout)
+}
+
+// Raw switches stdin to raw mode to read single key presses. The returned
+// function restores the previous terminal state.
+func Raw() (func(), error) {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() { _ = term.Restore(fd, oldState) }, nil
+}
+
+// Keys returns the channel of key presses read from stdin. Reading starts
+// on first use; the channel is closed when stdin reaches end of file.
+func Keys() <-chan byte {
+	startOnce.Do(func() {
+		keys = make(chan byte, 16)
+		go func() {
+			defer close(keys)
+			buffer := make([]byte, 1)
+			for {
+				if count, err := os.Stdin.Read(buffer); err != nil || count == 0 {
+					return
+				}
+				keys <- buffer[0]
+			}
+		}()
+	})
+	return keys
+}
+
+// Drain discards key presses that arrived before they were wanted
+func Drain() {
+	if keys == nil {
+		return
+	}
+	for {
+		select {
+		case _, ok := <-keys:
+			if !ok {
+				return // Input has ended
+			}
+		default:
+			return
+		}
+	}
+}
+
+// NewlineWriter translates "\n" into "\r\n", since raw mode disables the
+// terminal's own translation and plain newlines would drift rightwards.
+func NewlineWriter(w io.Writer) io.Writer {
+	return crlfWriter{w}
+}
+
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (c crlfWriter) Write(p []byte) (int, error) {
+	start := 0
+	for i, b := range p {
+		if b != '\n' || (i > 0 && p[i-1] == '\r') {
+			continue
+		}
+		if _, err := c.w.Write(p[start:i]); err != nil {
+			return start, err
+		}
+		if _, err := c.w.Write([]byte("\r\n")); err != nil {
+			return i, err
+		}
+		start = i + 1
+	}
+	if _, err := c.w.Write(p[start:]); err != nil {
+		return start, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,26 @@
+package tty
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewlineWriter(t *testing.T) {
+	testCases := []struct{ input, want string }{
+		{"one\ntwo\n", "one\r\ntwo\r\n"},
+		{"already\r\nfine", "already\r\nfine"},
+		{"no newline", "no newline"},
+		{"\n\n", "\r\n\r\n"},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		n, err := NewlineWriter(&buf).Write([]byte(tc.input))
+		if err != nil || n != len(tc.input) {
+			t.Errorf("Write(%q) = %d, %v", tc.input, n, err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("Write(%q) produced %q, want %q", tc.input, buf.String(), tc.want)
+		}
+	}
+}
@@ -25,8 +25,32 @@ func main() {
 		cli.HandleNow(os.Args[2:])
 	case "anchor":
 		cli.HandleAnchor(os.Args[2:])
+	case "count":
+		cli.HandleCount(os.Args[2:])
+	case "note-practice":
+		cli.HandleNotePractice(os.Args[2:])
+	case "sit":
+		cli.HandleSit(os.Args[2:])
+	case "hold":
+		cli.HandleHold(os.Args[2:])
+	case "guide":
+		cli.HandleGuide(os.Args[2:], programName)
+	case "program":
+		cli.HandleProgram(os.Args[2:], programName)
+	case "today":
+		cli.HandleToday(os.Args[2:], programName)
 	case "reflect":
 		cli.HandleReflect(os.Args[2:])
+	case "quote":
+		cli.HandleQuote(os.Args[2:], programName)
+	case "git":
+		cli.HandleGit(os.Args[2:], programName)
+	case "shell-init":
+		cli.HandleShellInit(os.Args[2:], programName)
+	case "shell-nudge":
+		cli.HandleShellNudge(os.Args[2:])
+	case "vault":
+		cli.HandleVault(os.Args[2:], programName)
 	case "help":
 		cli.ShowHelp(programName)
 	case "version", "--version", "-v":